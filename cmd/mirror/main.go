@@ -0,0 +1,170 @@
+// Command mirror provides development tooling for mirror.
+//
+// Usage:
+//
+//	mirror watch [-dir ./...] [-interval 500ms] [-debounce 200ms] -- go run ./cmd/gen
+//
+//	mirror diff [-json] old.json new.json
+//
+// The watch command runs the generator command (the program that calls `GenerateAndSaveAll`) once and then again every time a Go file in the watched directories changes.
+// Go types cannot be reloaded in a running program, so the generator is always run as a separate process.
+//
+// The diff command compares two IR documents (see `Mirror.ExportIR`) and exits with a non-zero status if there are breaking changes, which makes it suitable for gating CI.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.trulyao.dev/mirror/v2/diff"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/watch"
+)
+
+// stringList is a flag that can be passed multiple times
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "watch":
+		err = runWatch(ctx, os.Args[2:])
+	case "diff":
+		err = runDiff(os.Args[2:])
+	case "help", "-h", "--help":
+		usage()
+		return
+	default:
+		err = fmt.Errorf("unknown command `%s`", os.Args[1])
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mirror: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: mirror <command> [arguments]
+
+commands:
+  watch [-dir ./...] [-interval 500ms] [-debounce 200ms] -- <generator command>
+        re-run the generator command whenever a Go file in the watched directories changes
+  diff [-json] <old.json> <new.json>
+        compare two IR documents and exit with a non-zero status if there are breaking changes`)
+}
+
+func runWatch(ctx context.Context, args []string) error {
+	var (
+		dirs     stringList
+		flags    = flag.NewFlagSet("watch", flag.ContinueOnError)
+		interval = flags.Duration("interval", 500*time.Millisecond, "how often to check for changes")
+		debounce = flags.Duration("debounce", 200*time.Millisecond, "how long to wait for changes to settle before regenerating")
+	)
+	flags.Var(&dirs, "dir", "directory to watch, directories ending in `/...` are watched recursively (can be repeated, defaults to ./...)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	command := flags.Args()
+	if len(command) == 0 {
+		return errors.New("no generator command provided, e.g. `mirror watch -- go run ./cmd/gen`")
+	}
+
+	if len(dirs) == 0 {
+		dirs = stringList{"./..."}
+	}
+
+	fmt.Fprintf(os.Stderr, "mirror: watching %s\n", strings.Join(dirs, ", "))
+	watch.Regenerate(ctx, os.Stderr, nil, command...)
+
+	// The watched directories can contain the generator itself (or the packages it is configured in), so the changed packages are not passed on and every target is regenerated
+	return watch.New(watch.Options{Interval: *interval, Debounce: *debounce}, dirs...).Run(ctx, func(changed []string) {
+		fmt.Fprintf(os.Stderr, "mirror: %d file(s) changed, regenerating\n", len(changed))
+		watch.Regenerate(ctx, os.Stderr, nil, command...)
+	})
+}
+
+var errBreakingChanges = errors.New("breaking changes detected")
+
+func runDiff(args []string) error {
+	var (
+		flags      = flag.NewFlagSet("diff", flag.ContinueOnError)
+		jsonOutput = flags.Bool("json", false, "print the report as JSON")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		return errors.New("expected exactly two IR documents, e.g. `mirror diff old.json new.json`")
+	}
+
+	oldDoc, err := readDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	newDoc, err := readDocument(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	report := diff.Compare(oldDoc, newDoc)
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println(report.String())
+	}
+
+	if report.Breaking {
+		return errBreakingChanges
+	}
+
+	return nil
+}
+
+func readDocument(name string) (*ir.Document, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	doc, err := ir.Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return doc, nil
+}
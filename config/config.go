@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"slices"
 
+	"go.trulyao.dev/mirror/v2/parser"
 	"go.trulyao.dev/mirror/v2/types"
 )
 
@@ -45,6 +46,16 @@ type Config struct {
 	// }
 	//
 	FlattenEmbeddedTypes bool
+
+	// Order is the order declarations are generated in; the order sources were added in (default), alphabetical or topological (referenced types first)
+	// Alphabetical and topological orders do not depend on the order sources are added in, so the output stays the same when sources are registered from different places
+	Order parser.Order
+
+	// SortFields will sort the fields of structs alphabetically (by their generated names) instead of keeping the order they are declared in
+	SortFields bool
+
+	// CreateMissingDirectories will create the targets' output paths if they do not exist instead of failing
+	CreateMissingDirectories bool
 }
 
 // DefaultConfig returns a new Config with default values (Mirror is disabled by default)
@@ -0,0 +1,489 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/ir"
+)
+
+// Kind is the kind of a change between two versions of a type model
+type Kind string
+
+const (
+	KindTypeAdded            Kind = "type_added"
+	KindTypeRemoved          Kind = "type_removed"
+	KindTypeChanged          Kind = "type_changed"
+	KindFieldAdded           Kind = "field_added"
+	KindFieldRemoved         Kind = "field_removed"
+	KindFieldRenamed         Kind = "field_renamed"
+	KindFieldTypeChanged     Kind = "field_type_changed"
+	KindFieldRequired        Kind = "field_became_required"
+	KindFieldOptional        Kind = "field_became_optional"
+	KindNullableWidened      Kind = "nullable_widened"
+	KindNullableNarrowed     Kind = "nullable_narrowed"
+	KindEnumMemberAdded      Kind = "enum_member_added"
+	KindEnumMemberRemoved    Kind = "enum_member_removed"
+	KindVariantAdded         Kind = "variant_added"
+	KindVariantRemoved       Kind = "variant_removed"
+	KindDiscriminatorRenamed Kind = "discriminator_renamed"
+)
+
+// Change is a single difference between two versions of a type model
+type Change struct {
+	Kind Kind `json:"kind"`
+
+	// Breaking reports whether the change can break existing consumers (e.g. clients built against the old version)
+	Breaking bool `json:"breaking"`
+
+	// Path is the location of the change (e.g. `User` or `User.address`)
+	Path string `json:"path"`
+
+	// Old and New describe the value before and after the change (if applicable)
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+
+	// Message is a human-readable description of the change
+	Message string `json:"message"`
+}
+
+// Report is the result of comparing two versions of a type model
+type Report struct {
+	// Breaking reports whether any of the changes is breaking
+	Breaking bool `json:"breaking"`
+
+	Changes []Change `json:"changes"`
+}
+
+// BreakingChanges returns only the breaking changes
+func (r *Report) BreakingChanges() []Change {
+	var changes []Change
+	for _, change := range r.Changes {
+		if change.Breaking {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// String returns a human-readable summary of the report, one change per line
+func (r *Report) String() string {
+	if len(r.Changes) == 0 {
+		return "no changes"
+	}
+
+	lines := make([]string, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		severity := "non-breaking"
+		if change.Breaking {
+			severity = "BREAKING"
+		}
+
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", severity, change.Path, change.Message))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// comparer holds the state of a comparison
+type comparer struct {
+	report *Report
+
+	// compared holds the named types that have already been compared, so that shared and recursive types are only compared once
+	compared map[string]struct{}
+}
+
+// Compare compares two versions of a type model and classifies every change as breaking or non-breaking
+// The types are assumed to be used in both directions (requests and responses), so any change that can break a client reading or writing the old shape is considered breaking:
+//   - removing a type, field, enum member or union variant
+//   - renaming a field (a field with the same Go name but a different serialized name)
+//   - changing the type of a field
+//   - making an optional field required or a required field optional
+//   - making a field nullable (nullable widening)
+//   - adding a required field
+func Compare(oldDoc, newDoc *ir.Document) *Report {
+	c := &comparer{report: &Report{Changes: []Change{}}, compared: make(map[string]struct{})}
+
+	oldItems, newItems := indexItems(oldDoc), indexItems(newDoc)
+
+	for _, key := range sortedKeys(oldItems) {
+		oldNode := oldItems[key]
+		newNode, ok := newItems[key]
+		if !ok {
+			c.add(Change{Kind: KindTypeRemoved, Breaking: true, Path: oldNode.Name, Message: "type was removed"})
+			continue
+		}
+
+		c.compareNodes(oldNode.Name, oldNode, newNode)
+	}
+
+	for _, key := range sortedKeys(newItems) {
+		if _, ok := oldItems[key]; !ok {
+			c.add(Change{Kind: KindTypeAdded, Path: newItems[key].Name, Message: "type was added"})
+		}
+	}
+
+	return c.report
+}
+
+func (c *comparer) add(change Change) {
+	c.report.Changes = append(c.report.Changes, change)
+	if change.Breaking {
+		c.report.Breaking = true
+	}
+}
+
+// compareNodes compares two versions of the same type
+func (c *comparer) compareNodes(path string, oldNode, newNode *ir.Node) {
+	if oldNode.Kind == ir.KindStruct || oldNode.Kind == ir.KindUnion {
+		key := qualifiedName(oldNode)
+		if _, ok := c.compared[key]; ok && oldNode.Name != "" {
+			return
+		}
+		c.compared[key] = struct{}{}
+	}
+
+	// The content of structs and unions is compared field by field (or variant by variant) instead
+	oldSignature, newSignature := signature(oldNode), signature(newNode)
+	if oldNode.Kind == newNode.Kind && (oldNode.Kind == ir.KindStruct || oldNode.Kind == ir.KindUnion) {
+		oldSignature, newSignature = string(oldNode.Kind), string(newNode.Kind)
+	}
+
+	if oldSignature != newSignature {
+		c.add(Change{
+			Kind:     KindTypeChanged,
+			Breaking: true,
+			Path:     path,
+			Old:      oldSignature,
+			New:      newSignature,
+			Message:  fmt.Sprintf("type changed from `%s` to `%s`", oldSignature, newSignature),
+		})
+		return
+	}
+
+	switch oldNode.Kind {
+	case ir.KindStruct:
+		c.compareFields(oldNode, newNode)
+	case ir.KindUnion:
+		c.compareVariants(path, oldNode, newNode)
+	case ir.KindScalar:
+		c.compareMembers(path, oldNode.Enum, newNode.Enum, KindEnumMemberRemoved, KindEnumMemberAdded, "enum member")
+	default:
+		c.compareChildren(path, oldNode, newNode)
+	}
+}
+
+// compareChildren compares the named types nested in lists, maps and functions (e.g. the struct in `[]User`)
+func (c *comparer) compareChildren(path string, oldNode, newNode *ir.Node) {
+	var oldChildren, newChildren []*ir.Node
+
+	switch oldNode.Kind {
+	case ir.KindList:
+		oldChildren, newChildren = []*ir.Node{oldNode.Elem}, []*ir.Node{newNode.Elem}
+	case ir.KindMap:
+		oldChildren, newChildren = []*ir.Node{oldNode.Key, oldNode.Value}, []*ir.Node{newNode.Key, newNode.Value}
+	case ir.KindFunction:
+		oldChildren = append(append(oldChildren, oldNode.Params...), oldNode.Returns...)
+		newChildren = append(append(newChildren, newNode.Params...), newNode.Returns...)
+	}
+
+	for idx := range oldChildren {
+		if idx >= len(newChildren) || oldChildren[idx] == nil || newChildren[idx] == nil {
+			continue
+		}
+
+		if child := oldChildren[idx]; (child.Kind == ir.KindStruct || child.Kind == ir.KindUnion) && child.Name != "" {
+			c.compareNodes(child.Name, child, newChildren[idx])
+		} else {
+			c.compareChildren(path, child, newChildren[idx])
+		}
+	}
+}
+
+// compareFields compares the fields of two versions of a struct
+func (c *comparer) compareFields(oldNode, newNode *ir.Node) {
+	typeName := oldNode.Name
+	oldFields, newFields := visibleFields(oldNode), visibleFields(newNode)
+
+	// Fields that were renamed are matched by their Go name, so they are neither reported as removed nor added
+	matched := make(map[string]struct{})
+
+	for _, oldField := range oldFields {
+		path := typeName + "." + oldField.Name
+
+		newField := findField(newFields, func(f ir.Field) bool { return f.Name == oldField.Name })
+		if newField == nil && oldField.OriginalName != "" {
+			newField = findField(newFields, func(f ir.Field) bool {
+				_, alreadyMatched := matched[f.Name]
+				return f.OriginalName == oldField.OriginalName && !alreadyMatched && findField(oldFields, func(o ir.Field) bool { return o.Name == f.Name }) == nil
+			})
+
+			if newField != nil {
+				c.add(Change{
+					Kind:     KindFieldRenamed,
+					Breaking: true,
+					Path:     path,
+					Old:      oldField.Name,
+					New:      newField.Name,
+					Message:  fmt.Sprintf("field was renamed from `%s` to `%s`", oldField.Name, newField.Name),
+				})
+			}
+		}
+
+		if newField == nil {
+			c.add(Change{Kind: KindFieldRemoved, Breaking: true, Path: path, Message: "field was removed"})
+			continue
+		}
+
+		matched[newField.Name] = struct{}{}
+		c.compareField(path, oldField, *newField)
+	}
+
+	for _, newField := range newFields {
+		if _, ok := matched[newField.Name]; ok {
+			continue
+		}
+
+		required := !isOptional(newField) && !newField.Item.Nullable
+		message := "optional field was added"
+		if required {
+			message = "required field was added"
+		}
+
+		c.add(Change{Kind: KindFieldAdded, Breaking: required, Path: typeName + "." + newField.Name, Message: message})
+	}
+}
+
+// compareField compares two versions of the same field
+func (c *comparer) compareField(path string, oldField, newField ir.Field) {
+	oldType, newType := fieldType(oldField), fieldType(newField)
+	if oldType != newType {
+		c.add(Change{
+			Kind:     KindFieldTypeChanged,
+			Breaking: true,
+			Path:     path,
+			Old:      oldType,
+			New:      newType,
+			Message:  fmt.Sprintf("type changed from `%s` to `%s`", oldType, newType),
+		})
+	} else if oldField.TypeOverride == "" && oldField.Item != nil && newField.Item != nil {
+		c.compareChildren(path, &ir.Node{Kind: ir.KindList, Elem: oldField.Item}, &ir.Node{Kind: ir.KindList, Elem: newField.Item})
+	}
+
+	switch oldOptional, newOptional := isOptional(oldField), isOptional(newField); {
+	case oldOptional && !newOptional:
+		c.add(Change{Kind: KindFieldRequired, Breaking: true, Path: path, Message: "optional field became required"})
+	case !oldOptional && newOptional:
+		c.add(Change{Kind: KindFieldOptional, Breaking: true, Path: path, Message: "required field became optional"})
+	}
+
+	if oldField.Item != nil && newField.Item != nil {
+		switch {
+		case !oldField.Item.Nullable && newField.Item.Nullable:
+			c.add(Change{Kind: KindNullableWidened, Breaking: true, Path: path, Message: "field became nullable"})
+		case oldField.Item.Nullable && !newField.Item.Nullable:
+			c.add(Change{Kind: KindNullableNarrowed, Path: path, Message: "field is no longer nullable"})
+		}
+	}
+
+	c.compareMembers(path, enumMembers(oldField), enumMembers(newField), KindEnumMemberRemoved, KindEnumMemberAdded, "enum member")
+}
+
+// compareVariants compares two versions of a union
+func (c *comparer) compareVariants(path string, oldNode, newNode *ir.Node) {
+	if oldNode.Discriminator != newNode.Discriminator {
+		c.add(Change{
+			Kind:     KindDiscriminatorRenamed,
+			Breaking: true,
+			Path:     path,
+			Old:      oldNode.Discriminator,
+			New:      newNode.Discriminator,
+			Message:  fmt.Sprintf("discriminator was renamed from `%s` to `%s`", oldNode.Discriminator, newNode.Discriminator),
+		})
+	}
+
+	oldTags, newTags := variantTags(oldNode), variantTags(newNode)
+	c.compareMembers(path, oldTags, newTags, KindVariantRemoved, KindVariantAdded, "variant")
+
+	for _, oldVariant := range oldNode.Variants {
+		for _, newVariant := range newNode.Variants {
+			if oldVariant.Tag == newVariant.Tag && oldVariant.Item != nil && newVariant.Item != nil {
+				c.compareNodes(path+"<"+oldVariant.Tag+">", oldVariant.Item, newVariant.Item)
+			}
+		}
+	}
+}
+
+// compareMembers reports the members (enum values, union tags) that were removed (breaking) or added (non-breaking)
+func (c *comparer) compareMembers(path string, oldMembers, newMembers []string, removed, added Kind, label string) {
+	for _, member := range oldMembers {
+		if !slices.Contains(newMembers, member) {
+			c.add(Change{Kind: removed, Breaking: true, Path: path, Old: member, Message: fmt.Sprintf("%s `%s` was removed", label, member)})
+		}
+	}
+
+	for _, member := range newMembers {
+		if !slices.Contains(oldMembers, member) {
+			c.add(Change{Kind: added, Path: path, New: member, Message: fmt.Sprintf("%s `%s` was added", label, member)})
+		}
+	}
+}
+
+// signature returns a compact description of a type, named structs and unions are described by name only since their content is compared separately
+// Nullability is not part of the signature, it is reported separately
+func signature(node *ir.Node) string {
+	if node == nil {
+		return "<nil>"
+	}
+
+	switch node.Kind {
+	case ir.KindScalar:
+		return string(node.Type)
+	case ir.KindList:
+		if node.Length >= 0 {
+			return fmt.Sprintf("[%d]%s", node.Length, elemSignature(node.Elem))
+		}
+
+		return "[]" + elemSignature(node.Elem)
+	case ir.KindMap:
+		return fmt.Sprintf("map[%s]%s", elemSignature(node.Key), elemSignature(node.Value))
+	case ir.KindFunction:
+		params := make([]string, 0, len(node.Params))
+		for _, param := range node.Params {
+			params = append(params, elemSignature(param))
+		}
+
+		returns := make([]string, 0, len(node.Returns))
+		for _, ret := range node.Returns {
+			returns = append(returns, elemSignature(ret))
+		}
+
+		return fmt.Sprintf("func(%s) (%s)", strings.Join(params, ", "), strings.Join(returns, ", "))
+	case ir.KindStruct:
+		fields := make([]string, 0, len(node.Fields))
+		for _, field := range visibleFields(node) {
+			fields = append(fields, field.Name+" "+fieldType(field))
+		}
+
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	case ir.KindUnion:
+		return "union(" + strings.Join(variantTags(node), " | ") + ")"
+	}
+
+	return string(node.Kind)
+}
+
+// elemSignature returns the signature of a nested type, named structs and unions are referenced by name and nullable types are marked with a `*`
+func elemSignature(node *ir.Node) string {
+	if node == nil {
+		return "<nil>"
+	}
+
+	sig := signature(node)
+	if (node.Kind == ir.KindStruct || node.Kind == ir.KindUnion) && node.Name != "" {
+		sig = node.Name
+	}
+
+	if node.Nullable {
+		sig = "*" + sig
+	}
+
+	return sig
+}
+
+// fieldType returns the type of a field as seen by consumers, i.e. the override type if the user provided one
+func fieldType(field ir.Field) string {
+	if field.TypeOverride != "" {
+		return field.TypeOverride
+	}
+
+	if field.Item == nil {
+		return "<nil>"
+	}
+
+	sig := signature(field.Item)
+	if (field.Item.Kind == ir.KindStruct || field.Item.Kind == ir.KindUnion) && field.Item.Name != "" {
+		sig = field.Item.Name
+	}
+
+	return sig
+}
+
+func isOptional(field ir.Field) bool {
+	return field.Optional == "true"
+}
+
+func enumMembers(field ir.Field) []string {
+	if field.Constraints == nil {
+		return nil
+	}
+
+	return field.Constraints.Enum
+}
+
+func variantTags(node *ir.Node) []string {
+	tags := make([]string, 0, len(node.Variants))
+	for _, variant := range node.Variants {
+		tags = append(tags, variant.Tag)
+	}
+
+	return tags
+}
+
+// visibleFields returns the fields that are not skipped (skipped fields are never seen by consumers)
+func visibleFields(node *ir.Node) []ir.Field {
+	fields := make([]ir.Field, 0, len(node.Fields))
+	for _, field := range node.Fields {
+		if !field.Skip {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func findField(fields []ir.Field, match func(ir.Field) bool) *ir.Field {
+	for idx := range fields {
+		if match(fields[idx]) {
+			return &fields[idx]
+		}
+	}
+
+	return nil
+}
+
+func qualifiedName(node *ir.Node) string {
+	if node.PkgPath == "" {
+		return node.Name
+	}
+
+	return node.PkgPath + "." + node.Name
+}
+
+// indexItems indexes the top-level items of a document by their qualified name
+func indexItems(doc *ir.Document) map[string]*ir.Node {
+	items := make(map[string]*ir.Node)
+	if doc == nil {
+		return items
+	}
+
+	for _, node := range doc.Items {
+		if node != nil {
+			items[qualifiedName(node)] = node
+		}
+	}
+
+	return items
+}
+
+func sortedKeys(items map[string]*ir.Node) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,182 @@
+package diff_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/diff"
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+var (
+	stringItem = &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	intItem    = &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, BitSize: 64}
+)
+
+func field(name string, item parser.Item, opts ...func(*parser.Field)) parser.Field {
+	f := parser.Field{ItemName: name, BaseItem: item, Meta: meta.Meta{Name: name, OriginalName: name}}
+	for _, opt := range opts {
+		opt(&f)
+	}
+
+	return f
+}
+
+func user(fields ...parser.Field) *parser.Struct {
+	return &parser.Struct{ItemName: "User", PkgPath: "example.com/app", Fields: fields}
+}
+
+func document(t *testing.T, items ...parser.Item) *ir.Document {
+	doc, err := ir.NewDocument(items...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return doc
+}
+
+func Test_Compare(t *testing.T) {
+	optional := func(f *parser.Field) { f.Meta.Optional = meta.OptionalTrue }
+	originalName := func(name string) func(*parser.Field) {
+		return func(f *parser.Field) { f.Meta.OriginalName = name }
+	}
+	enum := func(values ...string) func(*parser.Field) {
+		return func(f *parser.Field) { f.Meta.Constraints.Enum = values }
+	}
+	nullableString := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true}
+
+	tests := []struct {
+		Description string
+		Old         []parser.Item
+		New         []parser.Item
+		Expect      []diff.Change
+	}{
+		{
+			Description: "no changes",
+			Old:         []parser.Item{user(field("name", stringItem))},
+			New:         []parser.Item{user(field("name", stringItem))},
+			Expect:      []diff.Change{},
+		},
+		{
+			Description: "field removed and optional field added",
+			Old:         []parser.Item{user(field("name", stringItem), field("age", intItem))},
+			New:         []parser.Item{user(field("name", stringItem), field("bio", stringItem, optional))},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldRemoved, Breaking: true, Path: "User.age", Message: "field was removed"},
+				{Kind: diff.KindFieldAdded, Path: "User.bio", Message: "optional field was added"},
+			},
+		},
+		{
+			Description: "required field added",
+			Old:         []parser.Item{user(field("name", stringItem))},
+			New:         []parser.Item{user(field("name", stringItem), field("age", intItem))},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldAdded, Breaking: true, Path: "User.age", Message: "required field was added"},
+			},
+		},
+		{
+			Description: "field renamed",
+			Old:         []parser.Item{user(field("name", stringItem, originalName("Name")))},
+			New:         []parser.Item{user(field("full_name", stringItem, originalName("Name")))},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldRenamed, Breaking: true, Path: "User.name", Old: "name", New: "full_name", Message: "field was renamed from `name` to `full_name`"},
+			},
+		},
+		{
+			Description: "field type changed",
+			Old:         []parser.Item{user(field("age", intItem))},
+			New:         []parser.Item{user(field("age", stringItem))},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldTypeChanged, Breaking: true, Path: "User.age", Old: "int", New: "string", Message: "type changed from `int` to `string`"},
+			},
+		},
+		{
+			Description: "optional field became required",
+			Old:         []parser.Item{user(field("name", stringItem, optional))},
+			New:         []parser.Item{user(field("name", stringItem))},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldRequired, Breaking: true, Path: "User.name", Message: "optional field became required"},
+			},
+		},
+		{
+			Description: "nullable widened and narrowed",
+			Old:         []parser.Item{user(field("name", stringItem), field("bio", nullableString))},
+			New:         []parser.Item{user(field("name", nullableString), field("bio", stringItem))},
+			Expect: []diff.Change{
+				{Kind: diff.KindNullableWidened, Breaking: true, Path: "User.name", Message: "field became nullable"},
+				{Kind: diff.KindNullableNarrowed, Path: "User.bio", Message: "field is no longer nullable"},
+			},
+		},
+		{
+			Description: "enum member removed and added",
+			Old:         []parser.Item{user(field("role", stringItem, enum("admin", "user")))},
+			New:         []parser.Item{user(field("role", stringItem, enum("user", "guest")))},
+			Expect: []diff.Change{
+				{Kind: diff.KindEnumMemberRemoved, Breaking: true, Path: "User.role", Old: "admin", Message: "enum member `admin` was removed"},
+				{Kind: diff.KindEnumMemberAdded, Path: "User.role", New: "guest", Message: "enum member `guest` was added"},
+			},
+		},
+		{
+			Description: "enum type value removed and added",
+			Old:         []parser.Item{&parser.Scalar{ItemName: "Role", ItemType: parser.TypeString, Named: true, Enum: []string{`"admin"`, `"user"`}}},
+			New:         []parser.Item{&parser.Scalar{ItemName: "Role", ItemType: parser.TypeString, Named: true, Enum: []string{`"user"`, `"guest"`}}},
+			Expect: []diff.Change{
+				{Kind: diff.KindEnumMemberRemoved, Breaking: true, Path: "Role", Old: `"admin"`, Message: "enum member `\"admin\"` was removed"},
+				{Kind: diff.KindEnumMemberAdded, Path: "Role", New: `"guest"`, Message: "enum member `\"guest\"` was added"},
+			},
+		},
+		{
+			Description: "nested struct field removed",
+			Old: []parser.Item{&parser.List{
+				ItemName: "Users",
+				Length:   parser.EmptyLength,
+				BaseItem: user(field("name", stringItem), field("age", intItem)),
+			}},
+			New: []parser.Item{&parser.List{
+				ItemName: "Users",
+				Length:   parser.EmptyLength,
+				BaseItem: user(field("name", stringItem)),
+			}},
+			Expect: []diff.Change{
+				{Kind: diff.KindFieldRemoved, Breaking: true, Path: "User.age", Message: "field was removed"},
+			},
+		},
+		{
+			Description: "union variant removed",
+			Old: []parser.Item{&parser.Union{ItemName: "Event", Discriminator: "type", Variants: []parser.UnionVariant{
+				{Tag: "created", Item: &parser.Struct{ItemName: "Created"}},
+				{Tag: "deleted", Item: &parser.Struct{ItemName: "Deleted"}},
+			}}},
+			New: []parser.Item{&parser.Union{ItemName: "Event", Discriminator: "type", Variants: []parser.UnionVariant{
+				{Tag: "created", Item: &parser.Struct{ItemName: "Created"}},
+			}}},
+			Expect: []diff.Change{
+				{Kind: diff.KindVariantRemoved, Breaking: true, Path: "Event", Old: "deleted", Message: "variant `deleted` was removed"},
+			},
+		},
+		{
+			Description: "type removed and added",
+			Old:         []parser.Item{user(field("name", stringItem))},
+			New:         []parser.Item{&parser.Scalar{ItemName: "Email", PkgPath: "example.com/app", ItemType: parser.TypeString}},
+			Expect: []diff.Change{
+				{Kind: diff.KindTypeRemoved, Breaking: true, Path: "User", Message: "type was removed"},
+				{Kind: diff.KindTypeAdded, Path: "Email", Message: "type was added"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		report := diff.Compare(document(t, test.Old...), document(t, test.New...))
+
+		if !reflect.DeepEqual(report.Changes, test.Expect) {
+			t.Errorf("[%s] expected %+v, got %+v", test.Description, test.Expect, report.Changes)
+		}
+
+		breaking := len(report.BreakingChanges()) > 0
+		if report.Breaking != breaking {
+			t.Errorf("[%s] expected breaking to be %v, got %v", test.Description, breaking, report.Breaking)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Package endpoint describes HTTP endpoints (their method, path and request/response types) that targets can generate typed clients for
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/helper"
+)
+
+// Endpoint describes a single HTTP endpoint
+type Endpoint struct {
+	// Name is the name of the endpoint in the generated clients (e.g. `getUser`), it is derived from the method and path when empty
+	Name string
+
+	// Method is the HTTP method of the endpoint (e.g. `GET`)
+	Method string
+
+	// Path is the path of the endpoint, path parameters are written in braces (e.g. `/users/{id}`), wildcards (`{path...}`) and patterns (`{id:[0-9]+}`) are supported as well
+	Path string
+
+	// Request is the type of the request, it is sent as the query string for methods without a body (e.g. `GET`) and as a JSON body otherwise, nil if the endpoint takes no request
+	Request reflect.Type
+
+	// Response is the type of the (JSON) response body, nil if the endpoint returns no content
+	Response reflect.Type
+}
+
+// Param is a parameter in the path of an endpoint
+type Param struct {
+	// Name is the name of the parameter (e.g. `id` for `/users/{id}`)
+	Name string `json:"name"`
+
+	// Wildcard is true for parameters that match the rest of the path (e.g. `{path...}`)
+	Wildcard bool `json:"wildcard,omitempty"`
+}
+
+// methods are the HTTP methods endpoints can be registered with
+var methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// New returns a new endpoint for the method and path, the request and response are values of their types (e.g. `CreateUserRequest{}`) or nil
+func New(method, path string, request, response any) (Endpoint, error) {
+	e := Endpoint{
+		Method:   strings.ToUpper(method),
+		Path:     path,
+		Request:  reflect.TypeOf(request),
+		Response: reflect.TypeOf(response),
+	}
+
+	if err := e.Validate(); err != nil {
+		return Endpoint{}, err
+	}
+
+	return e, nil
+}
+
+// Validate checks that the endpoint has a supported method and a valid path
+func (e Endpoint) Validate() error {
+	if !slices.Contains(methods, e.Method) {
+		return fmt.Errorf("unsupported method `%s` for endpoint `%s`", e.Method, e.Path)
+	}
+
+	if !strings.HasPrefix(e.Path, "/") {
+		return fmt.Errorf("path `%s` must start with a `/`", e.Path)
+	}
+
+	for _, segment := range strings.Split(e.Path, "/") {
+		if strings.Count(segment, "{") != strings.Count(segment, "}") {
+			return fmt.Errorf("unbalanced braces in path `%s`", e.Path)
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && segment != "{$}" && paramName(segment) == "" {
+			return errors.New("path parameters must have a name")
+		}
+	}
+
+	return nil
+}
+
+// HasBody checks if the request is sent as the body of the request (i.e. the method is not `GET`, `HEAD`, `DELETE` or `OPTIONS`)
+func (e Endpoint) HasBody() bool {
+	switch e.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// Params returns the parameters in the path of the endpoint in the order they appear
+func (e Endpoint) Params() []Param {
+	var params []Param
+	for _, segment := range strings.Split(e.Path, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || segment == "{$}" {
+			continue
+		}
+
+		params = append(params, Param{Name: paramName(segment), Wildcard: strings.HasSuffix(segment, "...}")})
+	}
+
+	return params
+}
+
+// OperationName returns the name of the endpoint, derived from the method and path when it has not been set (e.g. `GET /users/{id}` -> `getUsersById`)
+func (e Endpoint) OperationName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+
+	name := strings.ToLower(e.Method)
+	for _, segment := range strings.Split(e.Path, "/") {
+		switch {
+		case segment == "" || segment == "{$}":
+			continue
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name += "By" + helper.ToPascalCase(paramName(segment))
+		default:
+			name += helper.ToPascalCase(segment)
+		}
+	}
+
+	return name
+}
+
+// paramName returns the name of a path parameter segment (e.g. `{id}`, `{path...}` or `{id:[0-9]+}`)
+func paramName(segment string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+	name = strings.TrimSuffix(name, "...")
+	name, _, _ = strings.Cut(name, ":")
+
+	return strings.TrimSpace(name)
+}
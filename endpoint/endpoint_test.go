@@ -0,0 +1,115 @@
+package endpoint_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/endpoint"
+)
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		Description string
+		Method      string
+		Path        string
+		WantErr     bool
+	}{
+		{Description: "valid endpoint", Method: "GET", Path: "/users/{id}"},
+		{Description: "lowercase method", Method: "post", Path: "/users"},
+		{Description: "unsupported method", Method: "CONNECT", Path: "/users", WantErr: true},
+		{Description: "relative path", Method: "GET", Path: "users", WantErr: true},
+		{Description: "unbalanced braces", Method: "GET", Path: "/users/{id", WantErr: true},
+		{Description: "unnamed parameter", Method: "GET", Path: "/users/{}", WantErr: true},
+	}
+
+	for _, test := range tests {
+		_, err := endpoint.New(test.Method, test.Path, nil, nil)
+		if test.WantErr && err == nil {
+			t.Errorf("[%s] expected an error, got nil", test.Description)
+		} else if !test.WantErr && err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.Description, err)
+		}
+	}
+}
+
+func Test_Params(t *testing.T) {
+	tests := []struct {
+		Description string
+		Path        string
+		Expect      []endpoint.Param
+	}{
+		{Description: "no parameters", Path: "/users", Expect: nil},
+		{Description: "single parameter", Path: "/users/{id}", Expect: []endpoint.Param{{Name: "id"}}},
+		{Description: "multiple parameters", Path: "/orgs/{org}/users/{id}", Expect: []endpoint.Param{{Name: "org"}, {Name: "id"}}},
+		{Description: "wildcard", Path: "/files/{path...}", Expect: []endpoint.Param{{Name: "path", Wildcard: true}}},
+		{Description: "pattern", Path: "/users/{id:[0-9]+}", Expect: []endpoint.Param{{Name: "id"}}},
+		{Description: "end of path", Path: "/users/{$}", Expect: nil},
+	}
+
+	for _, test := range tests {
+		if got := (endpoint.Endpoint{Method: "GET", Path: test.Path}).Params(); !reflect.DeepEqual(got, test.Expect) {
+			t.Errorf("[%s] expected %v, got %v", test.Description, test.Expect, got)
+		}
+	}
+}
+
+func Test_OperationName(t *testing.T) {
+	tests := []struct {
+		Description string
+		Endpoint    endpoint.Endpoint
+		Expect      string
+	}{
+		{Description: "static path", Endpoint: endpoint.Endpoint{Method: "GET", Path: "/users"}, Expect: "getUsers"},
+		{Description: "path parameter", Endpoint: endpoint.Endpoint{Method: "DELETE", Path: "/users/{id}"}, Expect: "deleteUsersById"},
+		{Description: "kebab case segment", Endpoint: endpoint.Endpoint{Method: "POST", Path: "/password-resets"}, Expect: "postPasswordResets"},
+		{Description: "explicit name", Endpoint: endpoint.Endpoint{Name: "getUser", Method: "GET", Path: "/users/{id}"}, Expect: "getUser"},
+	}
+
+	for _, test := range tests {
+		if got := test.Endpoint.OperationName(); got != test.Expect {
+			t.Errorf("[%s] expected %q, got %q", test.Description, test.Expect, got)
+		}
+	}
+}
+
+func Test_Manifest(t *testing.T) {
+	type User struct{}
+
+	manifest := endpoint.NewManifest(
+		endpoint.Endpoint{Method: "GET", Path: "/users/{id}", Response: reflect.TypeOf(User{})},
+		endpoint.Endpoint{Name: "listUsers", Method: "GET", Path: "/users", Response: reflect.TypeOf([]User{})},
+	)
+
+	var sb strings.Builder
+	if err := manifest.Write(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expect := `{
+  "version": 1,
+  "endpoints": [
+    {
+      "name": "getUsersById",
+      "method": "GET",
+      "path": "/users/{id}",
+      "params": [
+        {
+          "name": "id"
+        }
+      ],
+      "response": "endpoint_test.User"
+    },
+    {
+      "name": "listUsers",
+      "method": "GET",
+      "path": "/users",
+      "response": "[]endpoint_test.User"
+    }
+  ]
+}
+`
+	if sb.String() != expect {
+		t.Errorf("expected manifest:\n%s\ngot:\n%s", expect, sb.String())
+	}
+}
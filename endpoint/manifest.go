@@ -0,0 +1,64 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// ManifestVersion is the version of the manifest format, it is bumped on breaking changes to the format
+const ManifestVersion = 1
+
+// Manifest is a versioned, serializable description of endpoints for tools that do not have access to the Go types (the types themselves can be exported with the IR)
+type Manifest struct {
+	// Version is the format version of the manifest (see ManifestVersion)
+	Version int `json:"version"`
+
+	// Endpoints are the endpoints in the order they were registered
+	Endpoints []ManifestEndpoint `json:"endpoints"`
+}
+
+// ManifestEndpoint is a single endpoint in a manifest
+type ManifestEndpoint struct {
+	Name   string  `json:"name"`
+	Method string  `json:"method"`
+	Path   string  `json:"path"`
+	Params []Param `json:"params,omitempty"`
+
+	// Request and Response are the Go types of the request and response (e.g. `models.User` or `[]models.User`), empty if there is none
+	Request  string `json:"request,omitempty"`
+	Response string `json:"response,omitempty"`
+}
+
+// NewManifest returns a manifest of the endpoints with the current version
+func NewManifest(endpoints ...Endpoint) *Manifest {
+	manifest := &Manifest{Version: ManifestVersion, Endpoints: make([]ManifestEndpoint, 0, len(endpoints))}
+	for _, e := range endpoints {
+		manifest.Endpoints = append(manifest.Endpoints, ManifestEndpoint{
+			Name:     e.OperationName(),
+			Method:   e.Method,
+			Path:     e.Path,
+			Params:   e.Params(),
+			Request:  typeString(e.Request),
+			Response: typeString(e.Response),
+		})
+	}
+
+	return manifest
+}
+
+// Write writes the manifest as indented JSON
+func (m *Manifest) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(m)
+}
+
+func typeString(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.String()
+}
@@ -1,13 +1,53 @@
 package extractor
 
 import (
+	"encoding/xml"
 	"reflect"
 
 	jsonmeta "go.trulyao.dev/mirror/v2/extractor/json"
 	"go.trulyao.dev/mirror/v2/extractor/meta"
 	mirrormeta "go.trulyao.dev/mirror/v2/extractor/mirror"
+	tagmeta "go.trulyao.dev/mirror/v2/extractor/tag"
+	validatemeta "go.trulyao.dev/mirror/v2/extractor/validate"
 )
 
+// ExtractFunc extracts meta information from a struct field, the `root` is the meta produced by the previous extractors (if any) and is updated in place
+type ExtractFunc func(field reflect.StructField, root *meta.Meta) (*meta.Meta, error)
+
+// Extractor extracts meta information (name, optionality, skip etc) from a single struct tag
+// Extractors are chained by the parser, each one receiving the meta produced by the previous one, so later extractors take precedence over earlier ones
+type Extractor interface {
+	// Tag returns the name of the struct tag the extractor reads (e.g. "json")
+	Tag() string
+
+	// Extract extracts the meta information from the field and updates the root meta
+	Extract(field reflect.StructField, root *meta.Meta) (*meta.Meta, error)
+}
+
+type extractor struct {
+	tag string
+	fn  ExtractFunc
+}
+
+func (e *extractor) Tag() string { return e.tag }
+
+func (e *extractor) Extract(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
+	return e.fn(field, root)
+}
+
+// New creates a new extractor for the provided tag using a custom extract function
+func New(tag string, fn ExtractFunc) Extractor {
+	return &extractor{tag: tag, fn: fn}
+}
+
+// ForTag creates an extractor for any `name,directive,...` style struct tag (e.g. `form:"first_name,omitempty"`)
+// The optional directives are the directives that mark the field as optional, unknown directives are ignored
+func ForTag(tag string, optionalDirectives ...string) Extractor {
+	return New(tag, func(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
+		return tagmeta.Extract(tag, field, root, tagmeta.Options{OptionalDirectives: optionalDirectives})
+	})
+}
+
 // ExtractJSONMeta extracts meta information from a field with the `json` tag
 func ExtractJSONMeta(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
 	return jsonmeta.Extract(field, root)
@@ -17,3 +57,55 @@ func ExtractJSONMeta(field reflect.StructField, root *meta.Meta) (*meta.Meta, er
 func ExtractMirrorMeta(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
 	return mirrormeta.Extract(field, root)
 }
+
+// ExtractValidationMeta extracts validation constraints from a field with the `validate` or `binding` tag
+func ExtractValidationMeta(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
+	return validatemeta.Extract(field, root)
+}
+
+// ExtractXMLMeta extracts meta information from a field with the `xml` tag
+// The `XMLName` field (of type `xml.Name`) is always skipped since it only holds the name of the element
+func ExtractXMLMeta(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
+	fieldMeta, err := tagmeta.Extract("xml", field, root, tagmeta.Options{
+		OptionalDirectives: []string{"omitempty"},
+		NameSeparator:      ">",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if field.Type == reflect.TypeOf(xml.Name{}) {
+		fieldMeta.Skip = true
+	}
+
+	return fieldMeta, nil
+}
+
+// JSON returns the extractor for the `json` tag
+func JSON() Extractor { return New("json", ExtractJSONMeta) }
+
+// Mirror returns the extractor for the `mirror` tag
+func Mirror() Extractor { return New("mirror", ExtractMirrorMeta) }
+
+// Validation returns the extractor for the `validate` and `binding` tags (go-playground/validator syntax)
+func Validation() Extractor { return New("validate", ExtractValidationMeta) }
+
+// YAML returns the extractor for the `yaml` tag (as used by gopkg.in/yaml and friends)
+func YAML() Extractor { return ForTag("yaml", "omitempty") }
+
+// XML returns the extractor for the `xml` tag (as used by encoding/xml)
+func XML() Extractor { return New("xml", ExtractXMLMeta) }
+
+// TOML returns the extractor for the `toml` tag (as used by BurntSushi/toml and pelletier/go-toml)
+func TOML() Extractor { return ForTag("toml", "omitempty", "omitzero") }
+
+// BSON returns the extractor for the `bson` tag (as used by the official MongoDB driver)
+func BSON() Extractor { return ForTag("bson", "omitempty") }
+
+// MsgPack returns the extractor for the `msgpack` tag (as used by vmihailenco/msgpack)
+func MsgPack() Extractor { return ForTag("msgpack", "omitempty") }
+
+// Defaults returns the extractors used by the parser by default, the `mirror` tag always comes last so that it can override every other tag
+func Defaults() []Extractor {
+	return []Extractor{JSON(), Validation(), Mirror()}
+}
@@ -60,6 +60,7 @@ func Extract(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
 			return nil, fmt.Errorf("invalid JSON tag name: %s", name)
 		}
 		fieldMeta.Name = name
+		fieldMeta.ExplicitName = true
 	}
 
 	// Validate the second directive
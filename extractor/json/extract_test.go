@@ -53,6 +53,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Name",
 				Name:         "first_name",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalNone,
 			},
@@ -108,6 +109,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Formed",
 				Name:         "formed",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalTrue,
 			},
@@ -129,6 +131,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Dash",
 				Name:         "-",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalNone,
 			},
@@ -139,6 +142,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "WithNameOnly",
 				Name:         "name",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalNone,
 			},
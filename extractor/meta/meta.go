@@ -36,6 +36,9 @@ type Meta struct {
 	// Name is the name of the field in the target language usually overridden by the user via parser hooks or struct tags
 	Name string
 
+	// ExplicitName is a flag indicating if the name was set explicitly (via struct tags or parser hooks) instead of being inferred from the Go field, naming strategies are only applied to inferred names
+	ExplicitName bool
+
 	// Type is the type of the field in the target language usually overridden by the user via parser hooks or struct tags
 	Type string
 
@@ -44,4 +47,50 @@ type Meta struct {
 
 	// Skip is a flag indicating if the field should be skipped during generation
 	Skip bool
+
+	// Default is the default value of the field encoded as JSON (e.g. `"guest"`, `8080` or `[1, 2]`), it is empty if the field has no default value
+	// Defaults are set with the `default` attribute of the `mirror` tag or derived from the values of sources added with `AddSourceWithDefaults`
+	Default string
+
+	// Readonly is a flag indicating if the field should be immutable in the target language (e.g. `readonly` properties in Typescript)
+	Readonly bool
+
+	// Constraints are the validation rules of the field, usually extracted from `validate` or `binding` struct tags
+	Constraints Constraints
+}
+
+// Constraints describes the validation rules of a field in a target-agnostic way so that generators can use them (e.g. to generate schemas)
+type Constraints struct {
+	// Required indicates that the field must be present (and not empty)
+	Required bool
+
+	// MinLength is the minimum length of a string, list or map
+	MinLength *int
+
+	// MaxLength is the maximum length of a string, list or map
+	MaxLength *int
+
+	// Minimum is the lower bound of a number, it is inclusive unless `ExclusiveMinimum` is set
+	Minimum *float64
+
+	// Maximum is the upper bound of a number, it is inclusive unless `ExclusiveMaximum` is set
+	Maximum *float64
+
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+
+	// Pattern is a regular expression the value must match
+	Pattern string
+
+	// Enum is the list of allowed values (e.g. from `oneof=a b c`)
+	Enum []string
+
+	// Format is a well-known format the value must be in (e.g. "email", "uuid", "url")
+	Format string
+}
+
+// IsEmpty reports whether no constraints have been set
+func (c Constraints) IsEmpty() bool {
+	return !c.Required && c.MinLength == nil && c.MaxLength == nil && c.Minimum == nil &&
+		c.Maximum == nil && c.Pattern == "" && len(c.Enum) == 0 && c.Format == ""
 }
@@ -1,7 +1,11 @@
 package mirrormeta
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"go.trulyao.dev/mirror/v2/extractor/meta"
@@ -47,15 +51,74 @@ func Extract(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
 	// Update the field meta with the parsed meta
 	if parsedMeta.Name != nil {
 		fieldMeta.Name = *parsedMeta.Name
+		fieldMeta.ExplicitName = true
 	}
 
 	if parsedMeta.Optional != meta.OptionalNone {
 		fieldMeta.Optional = parsedMeta.Optional
 	}
 
+	if parsedMeta.Readonly != nil {
+		fieldMeta.Readonly = *parsedMeta.Readonly
+	}
+
+	if parsedMeta.Default != nil {
+		if fieldMeta.Default, err = encodeDefault(*parsedMeta.Default, field.Type); err != nil {
+			return nil, fmt.Errorf("invalid default value for field `%s`: %w", field.Name, err)
+		}
+	}
+
 	if parsedMeta.Type != nil {
 		fieldMeta.Type = *parsedMeta.Type
 	}
 
 	return fieldMeta, nil
 }
+
+// encodeDefault converts a default value from the mirror tag to JSON based on the type of the field
+// Strings do not need to be quoted (e.g. `default:guest`), while lists, maps and structs must be written as JSON (e.g. `default:[1, 2]`)
+func encodeDefault(value string, fieldType reflect.Type) (string, error) {
+	for fieldType.Kind() == reflect.Pointer {
+		if value == "null" {
+			return value, nil
+		}
+
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatBool(b), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err := strconv.ParseInt(value, 10, fieldType.Bits()); err != nil {
+			return "", err
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, err := strconv.ParseUint(value, 10, fieldType.Bits()); err != nil {
+			return "", err
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(value, fieldType.Bits()); err != nil {
+			return "", err
+		}
+
+	default:
+		if !json.Valid([]byte(value)) {
+			return "", errors.New("expected a JSON value")
+		}
+	}
+
+	return value, nil
+}
@@ -19,6 +19,11 @@ type TestStruct struct {
 	Connections []string  `mirror:"name:connected_ids, type:Array<string>, optional:true"`
 	Meta        any       `mirror:"name:meta, type:{'foo': string},"`
 	CreatedAt   time.Time `mirror:"type:Date,skip:true,optional:true"`
+	ID          string    `mirror:"name:id,readonly"`
+	Role        string    `mirror:"default:guest"`
+	Port        *int      `mirror:"name:port, default:8080"`
+	Tags        []string  `mirror:"default:[\"a\"]"`
+	BadPort     int       `mirror:"default:http"`
 }
 
 var testStruct = reflect.TypeOf(TestStruct{})
@@ -33,6 +38,11 @@ func TestJSONTagParser_Parse(t *testing.T) {
 	connectionsField, _ := testStruct.FieldByName("Connections")
 	metaField, _ := testStruct.FieldByName("Meta")
 	createAtField, _ := testStruct.FieldByName("CreatedAt")
+	idField, _ := testStruct.FieldByName("ID")
+	roleField, _ := testStruct.FieldByName("Role")
+	portField, _ := testStruct.FieldByName("Port")
+	tagsField, _ := testStruct.FieldByName("Tags")
+	badPortField, _ := testStruct.FieldByName("BadPort")
 
 	tests := []struct {
 		Name     string
@@ -56,6 +66,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "LastName",
 				Name:         "last_name",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalNone,
 			},
@@ -76,6 +87,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Phone",
 				Name:         "phone_number",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalTrue,
 			},
@@ -96,6 +108,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "NextOfKin",
 				Name:         "next_of_kin",
+				ExplicitName: true,
 				Skip:         true,
 				Optional:     meta.OptionalNone,
 			},
@@ -106,6 +119,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Connections",
 				Name:         "connected_ids",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalTrue,
 				Type:         "Array<string>",
@@ -117,6 +131,7 @@ func TestJSONTagParser_Parse(t *testing.T) {
 			Expected: &meta.Meta{
 				OriginalName: "Meta",
 				Name:         "meta",
+				ExplicitName: true,
 				Skip:         false,
 				Optional:     meta.OptionalNone,
 				Type:         "{'foo': string}",
@@ -133,6 +148,37 @@ func TestJSONTagParser_Parse(t *testing.T) {
 				Type:         "Date",
 			},
 		},
+		{
+			Name:   "parse name and readonly flag",
+			Source: idField,
+			Expected: &meta.Meta{
+				OriginalName: "ID",
+				Name:         "id",
+				ExplicitName: true,
+				Optional:     meta.OptionalNone,
+				Readonly:     true,
+			},
+		},
+		{
+			Name:     "parse unquoted string default",
+			Source:   roleField,
+			Expected: &meta.Meta{OriginalName: "Role", Name: "Role", Default: `"guest"`},
+		},
+		{
+			Name:     "parse number default for pointer field",
+			Source:   portField,
+			Expected: &meta.Meta{OriginalName: "Port", Name: "port", ExplicitName: true, Default: "8080"},
+		},
+		{
+			Name:     "parse JSON default",
+			Source:   tagsField,
+			Expected: &meta.Meta{OriginalName: "Tags", Name: "Tags", Default: `["a"]`},
+		},
+		{
+			Name:    "fail to parse invalid number default",
+			Source:  badPortField,
+			WantErr: true,
+		},
 	}
 
 	for _, test := range tests {
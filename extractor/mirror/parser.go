@@ -34,6 +34,8 @@ const (
 	AtributeType
 	AttributeSkip
 	AtributeOptional
+	AttributeReadonly
+	AttributeDefault
 )
 
 const (
@@ -44,6 +46,8 @@ type ParsedMeta struct {
 	Name     *string
 	Type     *string
 	Skip     *bool
+	Readonly *bool
+	Default  *string
 	Optional mt.Optional
 }
 
@@ -62,6 +66,15 @@ func NewMetaParser(input string) *MetaParser {
 func (p *MetaParser) Parse() (*ParsedMeta, error) {
 	var meta ParsedMeta
 
+	// Find a `default:...` part of the string first, since the value can be almost anything (e.g. `default:[1, 2]`)
+	defaultValue, err := p.parseValue("default")
+	if err != nil {
+		return nil, err
+	}
+	if defaultValue != nil {
+		meta.Default = defaultValue
+	}
+
 	// Find a valid `optional:1|0|true|false` part of the string
 	optional, err := p.parseBool("optional")
 	if err != nil {
@@ -84,6 +97,18 @@ func (p *MetaParser) Parse() (*ParsedMeta, error) {
 		meta.Skip = skip
 	}
 
+	// Find a valid `readonly:1|0|true|false` or a bare `readonly` part of the string
+	readonly, err := p.parseBool("readonly")
+	if err != nil {
+		return nil, err
+	}
+	if readonly == nil && p.parseFlag("readonly") {
+		readonly = ref(true)
+	}
+	if readonly != nil {
+		meta.Readonly = readonly
+	}
+
 	// Find a valid `name:...` part of the string, valid names are alphanumeric, underscores and dashes
 	name, err := p.parseName()
 	if err != nil {
@@ -228,6 +253,90 @@ func (p *MetaParser) parseBool(attribute string) (*bool, error) {
 	return ref(booleanValue), nil
 }
 
+// parseValue finds an attribute outside of any block and reads its value up to the next comma that is not in a block (e.g. `default:[1, 2]`)
+func (p *MetaParser) parseValue(attribute string) (*string, error) {
+	attribute = attribute + ":"
+
+	var (
+		blocks     []rune
+		rangeStart = -1
+	)
+
+	for i, r := range p.input {
+		if rangeStart == -1 {
+			if isBlockStart(r) && r != '"' {
+				blocks = append(blocks, r)
+			} else if isBlockEnd(r) && r != '"' && len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			} else if len(blocks) == 0 && p.readRange(i, len(attribute)) == attribute &&
+				(i == 0 || !isAlphaNumeric(rune(p.input[i-1]))) {
+				rangeStart = i
+			}
+
+			continue
+		}
+
+		// Read the value until the end of the attribute
+		if i < rangeStart+len(attribute) {
+			continue
+		}
+
+		if isBlockStart(r) && r != '"' {
+			blocks = append(blocks, r)
+		} else if isBlockEnd(r) && r != '"' {
+			if len(blocks) == 0 || !isMatchingBlock(blocks[len(blocks)-1], r) {
+				return nil, fmt.Errorf("unexpected block closing while parsing `%s`: %c", attribute, r)
+			}
+
+			blocks = blocks[:len(blocks)-1]
+		} else if r == ',' && len(blocks) == 0 {
+			value := p.input[rangeStart+len(attribute) : i]
+			p.truncateRange(rangeStart, i-rangeStart)
+			return ref(strings.TrimSpace(value)), nil
+		}
+	}
+
+	if rangeStart == -1 {
+		return nil, nil
+	}
+
+	if len(blocks) > 0 {
+		return nil, fmt.Errorf("unexpected block opening while parsing `%s`: %c", attribute, blocks[len(blocks)-1])
+	}
+
+	value := p.input[rangeStart+len(attribute):]
+	p.truncateRange(rangeStart, len(p.input)-rangeStart)
+	return ref(strings.TrimSpace(value)), nil
+}
+
+// parseFlag finds a bare attribute (e.g. `readonly`) that makes up a whole comma-separated part of the string outside of any block and removes it
+func (p *MetaParser) parseFlag(attribute string) bool {
+	var blocks int
+
+	for i, r := range p.input {
+		if isBlockStart(r) && r != '"' {
+			blocks++
+			continue
+		} else if isBlockEnd(r) && r != '"' {
+			blocks--
+			continue
+		}
+
+		if blocks > 0 || p.readRange(i, len(attribute)) != attribute {
+			continue
+		}
+
+		before := strings.TrimSpace(p.input[:i])
+		after := strings.TrimSpace(p.input[i+len(attribute):])
+		if (before == "" || strings.HasSuffix(before, ",")) && (after == "" || strings.HasPrefix(after, ",")) {
+			p.truncateRange(i, len(attribute))
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *MetaParser) readRange(start int, length int) string {
 	if start+length > len(p.input) {
 		return ""
@@ -110,6 +110,46 @@ func Test_Parse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"name:email, readonly",
+			ParsedMeta{Name: ref("email"), Readonly: ref(true)},
+			false,
+		},
+		{
+			"readonly:false, type:string",
+			ParsedMeta{Type: ref("string"), Readonly: ref(false)},
+			false,
+		},
+		{
+			"type:readonly string[], name:tags",
+			ParsedMeta{Type: ref("readonly string[]"), Name: ref("tags")},
+			false,
+		},
+		{
+			"readonly",
+			ParsedMeta{Readonly: ref(true)},
+			false,
+		},
+		{
+			"name:port, default:8080, optional:true",
+			ParsedMeta{Name: ref("port"), Default: ref("8080"), Optional: meta.OptionalTrue},
+			false,
+		},
+		{
+			"default:[1, 2, 3], type:number[]",
+			ParsedMeta{Type: ref("number[]"), Default: ref("[1, 2, 3]")},
+			false,
+		},
+		{
+			"type:{ default: string }",
+			ParsedMeta{Type: ref("{ default: string }")},
+			false,
+		},
+		{
+			"default:[1, 2",
+			ParsedMeta{},
+			true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -133,6 +173,14 @@ func Test_Parse(t *testing.T) {
 				t.Errorf("Expected skip `%v` but got `%v`", deref(tc.expected.Skip), deref(meta.Skip))
 			}
 
+			if deref(meta.Default) != deref(tc.expected.Default) {
+				t.Errorf("Expected default `%v` but got `%v`", deref(tc.expected.Default), deref(meta.Default))
+			}
+
+			if deref(meta.Readonly) != deref(tc.expected.Readonly) {
+				t.Errorf("Expected readonly `%v` but got `%v`", deref(tc.expected.Readonly), deref(meta.Readonly))
+			}
+
 			if meta.Optional != tc.expected.Optional {
 				t.Errorf("Expected optional `%s` but got `%s`", tc.expected.Optional, meta.Optional)
 			}
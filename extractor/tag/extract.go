@@ -0,0 +1,72 @@
+package tagmeta
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/helper"
+)
+
+// Options describes how a `name,directive,...` style struct tag (as used by yaml, toml, bson, msgpack etc) should be interpreted
+type Options struct {
+	// OptionalDirectives are the directives that mark a field as optional (e.g. "omitempty")
+	OptionalDirectives []string
+
+	// NameSeparator is used for tags that can describe a path to the value (e.g. `xml:"a>b>c"`), only the last segment is used as the name
+	NameSeparator string
+}
+
+// Extract extracts meta information from a field with a `name,directive,...` style struct tag
+// Unlike the JSON extractor, unknown directives are ignored since every library has its own set of directives (e.g. `inline`, `flow`, `minsize`)
+func Extract(tag string, field reflect.StructField, root *meta.Meta, opts Options) (*meta.Meta, error) {
+	var fieldMeta *meta.Meta
+
+	if root != nil {
+		fieldMeta = root
+	} else {
+		fieldMeta = new(meta.Meta)
+	}
+
+	fieldMeta.OriginalName = helper.WithDefaultString(fieldMeta.OriginalName, field.Name)
+	fieldMeta.Name = helper.WithDefaultString(fieldMeta.Name, field.Name)
+
+	value := strings.TrimSpace(field.Tag.Get(tag))
+
+	// If the tag is empty, return the fieldMeta as is so that we can proceed to the next tag if any
+	if value == "" {
+		return fieldMeta, nil
+	}
+
+	if value == "-" {
+		fieldMeta.Skip = true
+		return fieldMeta, nil
+	}
+
+	values := strings.Split(value, ",")
+
+	name := strings.TrimSpace(values[0])
+	if opts.NameSeparator != "" {
+		segments := strings.Split(name, opts.NameSeparator)
+		name = strings.TrimSpace(segments[len(segments)-1])
+	}
+
+	if name != "" {
+		if !meta.FieldNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid `%s` tag name: %s", tag, name)
+		}
+
+		fieldMeta.Name = name
+		fieldMeta.ExplicitName = true
+	}
+
+	for _, directive := range values[1:] {
+		if slices.Contains(opts.OptionalDirectives, strings.TrimSpace(directive)) {
+			fieldMeta.Optional = meta.OptionalTrue
+		}
+	}
+
+	return fieldMeta, nil
+}
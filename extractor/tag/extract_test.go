@@ -0,0 +1,118 @@
+package tagmeta_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	tagmeta "go.trulyao.dev/mirror/v2/extractor/tag"
+)
+
+type TestStruct struct {
+	Name      string `yaml:"first_name"`
+	OmitEmpty string `yaml:",omitempty"`
+	Skip      string `yaml:"-"`
+	Inline    string `yaml:"inline,inline,omitempty"`
+	Unknown   string `yaml:"unknown,flow"`
+	Tagless   string
+	Invalid   string `yaml:"first-name"`
+	Path      string `xml:"parent>child,omitempty"`
+}
+
+var testStruct = reflect.TypeOf(TestStruct{})
+
+func Test_Extract(t *testing.T) {
+	field := func(name string) reflect.StructField {
+		f, _ := testStruct.FieldByName(name)
+		return f
+	}
+
+	yaml := tagmeta.Options{OptionalDirectives: []string{"omitempty"}}
+	xml := tagmeta.Options{OptionalDirectives: []string{"omitempty"}, NameSeparator: ">"}
+
+	tests := []struct {
+		Name     string
+		Tag      string
+		Options  tagmeta.Options
+		Source   reflect.StructField
+		Expected *meta.Meta
+		WantErr  bool
+	}{
+		{
+			Name:     "parse name",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("Name"),
+			Expected: &meta.Meta{OriginalName: "Name", Name: "first_name", ExplicitName: true},
+		},
+		{
+			Name:     "parse omitempty without name",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("OmitEmpty"),
+			Expected: &meta.Meta{OriginalName: "OmitEmpty", Name: "OmitEmpty", Optional: meta.OptionalTrue},
+		},
+		{
+			Name:     "parse skip",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("Skip"),
+			Expected: &meta.Meta{OriginalName: "Skip", Name: "Skip", Skip: true},
+		},
+		{
+			Name:     "parse multiple directives",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("Inline"),
+			Expected: &meta.Meta{OriginalName: "Inline", Name: "inline", ExplicitName: true, Optional: meta.OptionalTrue},
+		},
+		{
+			Name:     "ignore unknown directives",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("Unknown"),
+			Expected: &meta.Meta{OriginalName: "Unknown", Name: "unknown", ExplicitName: true},
+		},
+		{
+			Name:     "parse field without tag",
+			Tag:      "yaml",
+			Options:  yaml,
+			Source:   field("Tagless"),
+			Expected: &meta.Meta{OriginalName: "Tagless", Name: "Tagless"},
+		},
+		{
+			Name:    "parse invalid name",
+			Tag:     "yaml",
+			Options: yaml,
+			Source:  field("Invalid"),
+			WantErr: true,
+		},
+		{
+			Name:     "parse path with name separator",
+			Tag:      "xml",
+			Options:  xml,
+			Source:   field("Path"),
+			Expected: &meta.Meta{OriginalName: "Path", Name: "child", ExplicitName: true, Optional: meta.OptionalTrue},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tagmeta.Extract(tt.Tag, tt.Source, nil, tt.Options)
+		if err != nil {
+			if !tt.WantErr {
+				t.Errorf("[%s] unexpected error: %s", tt.Name, err)
+			}
+
+			continue
+		}
+
+		if tt.WantErr {
+			t.Errorf("[%s] wanted error, got no error", tt.Name)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.Expected) {
+			t.Errorf("[%s] wanted %#v, got %#v", tt.Name, tt.Expected, got)
+		}
+	}
+}
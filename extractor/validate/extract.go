@@ -0,0 +1,208 @@
+package validatemeta
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/helper"
+)
+
+// tags are the struct tags read by the extractor, `validate` is used by go-playground/validator and `binding` by gin
+var tags = []string{"validate", "binding"}
+
+// formats are the validator rules that describe a well-known format, they are stored as-is in `meta.Constraints.Format`
+var formats = map[string]bool{
+	"email":       true,
+	"url":         true,
+	"http_url":    true,
+	"uri":         true,
+	"uuid":        true,
+	"uuid3":       true,
+	"uuid4":       true,
+	"uuid5":       true,
+	"ulid":        true,
+	"ip":          true,
+	"ipv4":        true,
+	"ipv6":        true,
+	"cidr":        true,
+	"hostname":    true,
+	"fqdn":        true,
+	"mac":         true,
+	"e164":        true,
+	"base64":      true,
+	"base64url":   true,
+	"hexadecimal": true,
+	"hexcolor":    true,
+	"alpha":       true,
+	"alphanum":    true,
+	"numeric":     true,
+	"number":      true,
+	"json":        true,
+	"jwt":         true,
+	"semver":      true,
+	"datetime":    true,
+	"timezone":    true,
+	"lowercase":   true,
+	"uppercase":   true,
+}
+
+// Extract extracts the validation constraints from a field with the `validate` or `binding` tag (go-playground/validator syntax)
+// A `required` rule makes the field non-optional, overriding any previous optionality (e.g. from `omitempty`)
+func Extract(field reflect.StructField, root *meta.Meta) (*meta.Meta, error) {
+	var fieldMeta *meta.Meta
+
+	if root != nil {
+		fieldMeta = root
+	} else {
+		fieldMeta = new(meta.Meta)
+	}
+
+	fieldMeta.OriginalName = helper.WithDefaultString(fieldMeta.OriginalName, field.Name)
+	fieldMeta.Name = helper.WithDefaultString(fieldMeta.Name, field.Name)
+
+	for _, tag := range tags {
+		value := strings.TrimSpace(field.Tag.Get(tag))
+		if value == "" || value == "-" {
+			continue
+		}
+
+		if err := parseRules(value, field.Type, &fieldMeta.Constraints); err != nil {
+			return nil, fmt.Errorf("invalid `%s` tag: %w", tag, err)
+		}
+	}
+
+	if fieldMeta.Constraints.Required {
+		fieldMeta.Optional = meta.OptionalFalse
+	}
+
+	return fieldMeta, nil
+}
+
+func parseRules(value string, fieldType reflect.Type, constraints *meta.Constraints) error {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+
+		// Everything after `dive` applies to the elements of a list or map, not the field itself
+		if rule == "dive" || rule == "keys" {
+			break
+		}
+
+		// We can't represent "either of" rules (e.g. `email|url`) or empty rules
+		if rule == "" || strings.Contains(rule, "|") {
+			continue
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			constraints.Required = true
+
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			if err := parseBound(name, param, fieldType, constraints); err != nil {
+				return err
+			}
+
+		case "oneof":
+			constraints.Enum = splitOneOf(param)
+
+		case "regexp", "pattern":
+			constraints.Pattern = param
+
+		default:
+			if formats[name] {
+				constraints.Format = name
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseBound parses length rules for strings, lists and maps or range rules for numbers
+func parseBound(name, param string, fieldType reflect.Type, constraints *meta.Constraints) error {
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return fmt.Errorf("invalid `%s` length: %s", name, param)
+		}
+
+		switch name {
+		case "min", "gte":
+			constraints.MinLength = &n
+		case "gt":
+			constraints.MinLength = ref(n + 1)
+		case "max", "lte":
+			constraints.MaxLength = &n
+		case "lt":
+			constraints.MaxLength = ref(n - 1)
+		case "len":
+			constraints.MinLength, constraints.MaxLength = &n, ref(n)
+		}
+
+	default:
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			// Bounds on other types (e.g. durations or times) can't be represented as numbers
+			return nil
+		}
+
+		switch name {
+		case "min", "gte":
+			constraints.Minimum, constraints.ExclusiveMinimum = &n, false
+		case "gt":
+			constraints.Minimum, constraints.ExclusiveMinimum = &n, true
+		case "max", "lte":
+			constraints.Maximum, constraints.ExclusiveMaximum = &n, false
+		case "lt":
+			constraints.Maximum, constraints.ExclusiveMaximum = &n, true
+		case "len":
+			constraints.Minimum, constraints.Maximum = &n, ref(n)
+		}
+	}
+
+	return nil
+}
+
+// splitOneOf splits the values of a `oneof` rule, values are space-separated and can be wrapped in single quotes to include spaces (e.g. `oneof='hello world' foo`)
+func splitOneOf(param string) []string {
+	var (
+		values  []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			values = append(values, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range param {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return values
+}
+
+func ref[T any](t T) *T {
+	return &t
+}
@@ -0,0 +1,152 @@
+package validatemeta_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	validatemeta "go.trulyao.dev/mirror/v2/extractor/validate"
+)
+
+type TestStruct struct {
+	Username string        `json:"username,omitempty" validate:"required,min=3,max=64"`
+	Email    *string       `validate:"omitempty,email"`
+	Role     string        `binding:"required,oneof=admin 'super user' guest"`
+	Age      int           `validate:"gte=18,lt=130"`
+	Score    float64       `validate:"gt=0,lte=1.5"`
+	Tags     []string      `validate:"len=3,dive,required,min=1"`
+	Code     string        `validate:"regexp=^[A-Z]{3}$"`
+	Either   string        `validate:"email|url"`
+	Timeout  time.Duration `validate:"max=1h"`
+	Invalid  string        `validate:"min=abc"`
+	Untagged string
+}
+
+var testStruct = reflect.TypeOf(TestStruct{})
+
+func ref[T any](t T) *T {
+	return &t
+}
+
+func Test_Extract(t *testing.T) {
+	field := func(name string) reflect.StructField {
+		f, _ := testStruct.FieldByName(name)
+		return f
+	}
+
+	tests := []struct {
+		Name     string
+		Source   reflect.StructField
+		Root     *meta.Meta
+		Expected *meta.Meta
+		WantErr  bool
+	}{
+		{
+			Name:   "required overrides optional",
+			Source: field("Username"),
+			Root:   &meta.Meta{OriginalName: "Username", Name: "username", Optional: meta.OptionalTrue},
+			Expected: &meta.Meta{
+				OriginalName: "Username",
+				Name:         "username",
+				Optional:     meta.OptionalFalse,
+				Constraints:  meta.Constraints{Required: true, MinLength: ref(3), MaxLength: ref(64)},
+			},
+		},
+		{
+			Name:   "format",
+			Source: field("Email"),
+			Expected: &meta.Meta{
+				OriginalName: "Email",
+				Name:         "Email",
+				Constraints:  meta.Constraints{Format: "email"},
+			},
+		},
+		{
+			Name:   "binding tag with enum",
+			Source: field("Role"),
+			Expected: &meta.Meta{
+				OriginalName: "Role",
+				Name:         "Role",
+				Optional:     meta.OptionalFalse,
+				Constraints:  meta.Constraints{Required: true, Enum: []string{"admin", "super user", "guest"}},
+			},
+		},
+		{
+			Name:   "integer range",
+			Source: field("Age"),
+			Expected: &meta.Meta{
+				OriginalName: "Age",
+				Name:         "Age",
+				Constraints:  meta.Constraints{Minimum: ref(18.0), Maximum: ref(130.0), ExclusiveMaximum: true},
+			},
+		},
+		{
+			Name:   "float range",
+			Source: field("Score"),
+			Expected: &meta.Meta{
+				OriginalName: "Score",
+				Name:         "Score",
+				Constraints:  meta.Constraints{Minimum: ref(0.0), ExclusiveMinimum: true, Maximum: ref(1.5)},
+			},
+		},
+		{
+			Name:   "rules after dive are ignored",
+			Source: field("Tags"),
+			Expected: &meta.Meta{
+				OriginalName: "Tags",
+				Name:         "Tags",
+				Constraints:  meta.Constraints{MinLength: ref(3), MaxLength: ref(3)},
+			},
+		},
+		{
+			Name:   "pattern",
+			Source: field("Code"),
+			Expected: &meta.Meta{
+				OriginalName: "Code",
+				Name:         "Code",
+				Constraints:  meta.Constraints{Pattern: "^[A-Z]{3}$"},
+			},
+		},
+		{
+			Name:     "either rules are ignored",
+			Source:   field("Either"),
+			Expected: &meta.Meta{OriginalName: "Either", Name: "Either"},
+		},
+		{
+			Name:     "non-numeric bounds on numbers are ignored",
+			Source:   field("Timeout"),
+			Expected: &meta.Meta{OriginalName: "Timeout", Name: "Timeout"},
+		},
+		{
+			Name:    "invalid length",
+			Source:  field("Invalid"),
+			WantErr: true,
+		},
+		{
+			Name:     "untagged",
+			Source:   field("Untagged"),
+			Expected: &meta.Meta{OriginalName: "Untagged", Name: "Untagged"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := validatemeta.Extract(tt.Source, tt.Root)
+		if err != nil {
+			if !tt.WantErr {
+				t.Errorf("[%s] unexpected error: %s", tt.Name, err)
+			}
+
+			continue
+		}
+
+		if tt.WantErr {
+			t.Errorf("[%s] wanted error, got no error", tt.Name)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.Expected) {
+			t.Errorf("[%s] wanted %#v, got %#v", tt.Name, tt.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,491 @@
+package typescript
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/endpoint"
+	"go.trulyao.dev/mirror/v2/parser"
+	"go.trulyao.dev/mirror/v2/types"
+)
+
+// ClientConfig is the configuration for the typescript HTTP client generator, it implements the types.TargetInterface and is used to define a target that generates a typed client for the endpoints registered with `Mirror.AddEndpoint`
+// The client imports the request and response types from the file generated by the types target
+type ClientConfig struct {
+	// The generator for the current instance
+	generator *ClientGenerator
+
+	// FileName is the name of the generated file (defaults to `client`)
+	FileName string
+
+	// OutputPath is the path to write the generated file to
+	OutputPath string
+
+	// TypesImport is the module the types are imported from, relative to the client (e.g. `./generated`)
+	TypesImport string
+
+	// ErrorType is the name of the (Go) type of the body of failed responses (e.g. `ErrorResponse`), the type must be a source of the types target; the body is `unknown` if not set
+	ErrorType string
+
+	// Types is the config of the target the types are generated with, it is used to reference the types by the same names (i.e. prefix, suffix and naming strategy) and with the same options
+	Types *Config
+}
+
+// NewClient returns a new ClientConfig that imports the types generated with the provided (types) config, the client is written next to the types by default
+func NewClient(typesConfig *Config) *ClientConfig {
+	if typesConfig == nil {
+		typesConfig = DefaultConfig()
+	}
+
+	typesImport := "./" + strings.TrimSuffix(typesConfig.Name(), ".ts")
+	if typesConfig.OutputMode != OutputSingleFile {
+		typesImport = "./" + barrelFileName
+	}
+
+	return &ClientConfig{
+		FileName:    "client",
+		OutputPath:  typesConfig.OutputPath,
+		TypesImport: typesImport,
+		Types:       typesConfig,
+	}
+}
+
+// ID returns a unique identifier for a target
+func (c *ClientConfig) ID() string {
+	return strings.ReplaceAll(path.Join(c.OutputPath, c.Name()), "/", ":")
+}
+
+// IsEquivalent checks if two targets are equivalent
+func (c *ClientConfig) IsEquivalent(target types.TargetInterface) bool {
+	return c.ID() == target.ID()
+}
+
+// Prefix returns the prefix added to the referenced types
+func (c *ClientConfig) Prefix() string {
+	return c.Types.TypePrefix
+}
+
+// Name returns the name of the file
+func (c *ClientConfig) Name() string {
+	if strings.HasSuffix(c.FileName, ".ts") {
+		return c.FileName
+	}
+
+	return c.FileName + ".ts"
+}
+
+// Path returns the path to write the file to
+func (c *ClientConfig) Path() string {
+	return c.OutputPath
+}
+
+// Language returns the target language
+func (c *ClientConfig) Language() string { return "typescript" }
+
+// Extension returns the file extension
+func (c *ClientConfig) Extension() string { return "ts" }
+
+// Header returns the header text for the file, including the imports of the types config since inlined request and response types can use custom types
+func (c *ClientConfig) Header() string {
+	if c.Types == nil {
+		return fileHeader
+	}
+
+	return c.Types.Header()
+}
+
+// AddCustomType maps a fully-qualified Go type to a typescript type, this is added to the types config so that both targets agree on the type
+func (c *ClientConfig) AddCustomType(name, value string) {
+	c.Types.AddCustomType(name, value)
+}
+
+// SetFileName sets the name of the file to write to
+func (c *ClientConfig) SetFileName(name string) *ClientConfig {
+	c.FileName = name
+	return c
+}
+
+// SetOutputPath sets the path to write the file to
+func (c *ClientConfig) SetOutputPath(path string) *ClientConfig {
+	c.OutputPath = path
+	return c
+}
+
+// SetTypesImport sets the module the types are imported from, relative to the client (e.g. `./generated` or `@/types`)
+func (c *ClientConfig) SetTypesImport(module string) *ClientConfig {
+	c.TypesImport = module
+	return c
+}
+
+// SetErrorType sets the name of the (Go) type of the body of failed responses
+func (c *ClientConfig) SetErrorType(name string) *ClientConfig {
+	c.ErrorType = name
+	return c
+}
+
+// Generator returns a new client generator with the config
+func (c *ClientConfig) Generator() types.GeneratorInterface {
+	if c.generator == nil {
+		c.generator = NewClientGenerator(c)
+	}
+
+	return c.generator
+}
+
+// Validate() checks if the config is valid and passes as a valid target
+func (c *ClientConfig) Validate() error {
+	if c.FileName == "" {
+		return errors.New("no file name provided")
+	}
+
+	if c.OutputPath == "" {
+		return errors.New("no output path provided")
+	}
+
+	if c.TypesImport == "" {
+		return errors.New("no types import provided")
+	}
+
+	if c.Types == nil {
+		return errors.New("no types config provided")
+	}
+
+	return c.Types.Validate()
+}
+
+// ClientGenerator generates a typed client (`createClient`) for the registered endpoints
+type ClientGenerator struct {
+	// config is the configuration for the client
+	config *ClientConfig
+
+	// types is the generator used to reference and inline the request and response types
+	types *Generator
+
+	// endpoints are the endpoints to generate the client for
+	endpoints []endpoint.Endpoint
+}
+
+// NewClientGenerator returns a new client generator with the provided config
+func NewClientGenerator(c *ClientConfig) *ClientGenerator {
+	return &ClientGenerator{config: c, types: NewGenerator(c.Types)}
+}
+
+// SetNonStrict sets the generator to be non-strict, meaning it will not throw an error if a referenced type does not exist
+func (g *ClientGenerator) SetNonStrict(strict bool) {
+	g.types.SetNonStrict(strict)
+}
+
+// SetHeaderText sets the header text for the generated file
+func (g *ClientGenerator) SetHeaderText(header string) {
+	g.types.SetHeaderText(header)
+}
+
+// SetParser sets the parser used to parse the request and response types
+func (g *ClientGenerator) SetParser(parser types.ParserInterface) error {
+	return g.types.SetParser(parser)
+}
+
+// SetEndpoints sets the endpoints to generate the client for
+func (g *ClientGenerator) SetEndpoints(endpoints []endpoint.Endpoint) {
+	g.endpoints = endpoints
+}
+
+// GenerateItem generates a single item's declaration with the types config
+func (g *ClientGenerator) GenerateItem(item parser.Item) (string, error) {
+	return g.types.GenerateItem(item)
+}
+
+// GenerateItemType generates the type of a single item with the types config
+func (g *ClientGenerator) GenerateItemType(item parser.Item) (string, error) {
+	return g.types.GenerateItemType(item)
+}
+
+// GenerateN generates the client method of the nth endpoint
+func (g *ClientGenerator) GenerateN(idx int) (string, error) {
+	if idx < 0 || idx >= len(g.endpoints) {
+		return "", fmt.Errorf("endpoint index %d out of range", idx)
+	}
+
+	method, err := g.generateMethod(g.endpoints[idx])
+	if err != nil {
+		return "", err
+	}
+
+	return g.indented(method), nil
+}
+
+// GenerateAll generates the client module; the imports of the referenced types, the runtime (error type and request helpers) and the `createClient` function
+func (g *ClientGenerator) GenerateAll() ([]string, error) {
+	if g.types.parser == nil {
+		return nil, errors.New("no parser provided")
+	}
+
+	if len(g.endpoints) == 0 {
+		return nil, errors.New("no endpoints registered")
+	}
+
+	g.types.references = make(map[string]parser.Item)
+	defer func() { g.types.references = nil }()
+
+	var (
+		methods = make([]string, 0, len(g.endpoints))
+		names   = make(map[string]struct{}, len(g.endpoints))
+	)
+	for _, e := range g.endpoints {
+		name := e.OperationName()
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate endpoint name `%s` (%s %s), set a name for the endpoint", name, e.Method, e.Path)
+		}
+		names[name] = struct{}{}
+
+		method, err := g.generateMethod(e)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method)
+	}
+
+	errorType := "unknown"
+	if g.config.ErrorType != "" {
+		item, ok := g.types.parser.LookupByName(g.config.ErrorType)
+		if !ok {
+			return nil, fmt.Errorf("error type `%s` does not exist, you need to add it as a source", g.config.ErrorType)
+		}
+
+		errorType = g.types.reference(item)
+	}
+
+	var output []string
+	if imports := g.imports(); imports != "" {
+		output = append(output, imports)
+	}
+
+	output = append(
+		output,
+		g.indented(fmt.Sprintf(clientRuntime, errorType, errorType, errorType)),
+		g.indented(fmt.Sprintf(clientFactory, strings.Join(methods, "\n"))),
+		"export type Client = ReturnType<typeof createClient>;",
+	)
+
+	return output, nil
+}
+
+// generateMethod generates the client method for an endpoint (e.g. `getUser: (params: { id: string | number }): Promise<User> => send<User>("GET", ...),`)
+func (g *ClientGenerator) generateMethod(e endpoint.Endpoint) (string, error) {
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	var args, sendArgs []string
+
+	if params := e.Params(); len(params) > 0 {
+		fields := make([]string, 0, len(params))
+		for _, param := range params {
+			fields = append(fields, propertyName(param.Name)+": string | number")
+		}
+
+		args = append(args, "params: { "+strings.Join(fields, "; ")+" }")
+	}
+
+	sendArgs = append(sendArgs, fmt.Sprintf("%q", e.Method), g.pathExpression(e))
+
+	if e.Request != nil {
+		requestType, err := g.typeOf(e.Request)
+		if err != nil {
+			return "", err
+		}
+
+		if e.HasBody() {
+			args = append(args, "body: "+requestType)
+			sendArgs = append(sendArgs, "undefined", "body")
+		} else {
+			args = append(args, "query: "+requestType)
+			sendArgs = append(sendArgs, "query")
+		}
+	}
+
+	responseType := "void"
+	if e.Response != nil {
+		var err error
+		if responseType, err = g.typeOf(e.Response); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf(
+		"\t\t%s: (%s): Promise<%s> => send<%s>(%s),",
+		propertyName(e.OperationName()),
+		strings.Join(args, ", "),
+		responseType,
+		responseType,
+		strings.Join(sendArgs, ", "),
+	), nil
+}
+
+// pathExpression returns the (template) string for the path of an endpoint with the path parameters substituted and encoded
+func (g *ClientGenerator) pathExpression(e endpoint.Endpoint) string {
+	var (
+		segments = strings.Split(e.Path, "/")
+		params   = e.Params()
+		paramIdx int
+	)
+
+	for idx, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			segments[idx] = strings.NewReplacer("`", "\\`", "${", "\\${").Replace(segment)
+			continue
+		}
+
+		// `{$}` only matches the end of the path (i.e. the trailing slash) in Go 1.22 patterns
+		if segment == "{$}" {
+			segments[idx] = ""
+			continue
+		}
+
+		// Wildcards match the rest of the path, so their slashes are kept as they are
+		param := params[paramIdx]
+		paramIdx++
+
+		encode := "encodeURIComponent"
+		if param.Wildcard {
+			encode = "encodeURI"
+		}
+
+		segments[idx] = fmt.Sprintf("${%s(String(params[%q]))}", encode, param.Name)
+	}
+
+	if len(params) == 0 {
+		return fmt.Sprintf("%q", strings.Join(segments, "/"))
+	}
+
+	return "`" + strings.Join(segments, "/") + "`"
+}
+
+// typeOf returns the typescript type of a request or response, named objects are referenced (and imported) instead of being inlined
+func (g *ClientGenerator) typeOf(source reflect.Type) (string, error) {
+	item, err := g.types.parser.Parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	if g.types.isReference(item) {
+		if !g.types.referenceExists(item.Name()) {
+			return "", fmt.Errorf("referenced type `%s` does not exist, you need to either enable inline objects or pass in the referenced type", item.Name())
+		}
+
+		return g.types.reference(item), nil
+	}
+
+	return g.types.GenerateItemType(item)
+}
+
+// imports returns the `import type { ... } from "..."` statement for the referenced types
+func (g *ClientGenerator) imports() string {
+	if len(g.types.references) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(g.types.references))
+	for _, reference := range g.types.references {
+		if name := g.types.typeName(reference); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return fmt.Sprintf("import type { %s } from %q;", strings.Join(names, ", "), g.config.TypesImport)
+}
+
+// indented replaces the tabs used to indent the templates with the configured indentation
+func (g *ClientGenerator) indented(code string) string {
+	return strings.ReplaceAll(code, "\t", g.types.indent)
+}
+
+// clientRuntime is the code shared by every client, the error type of failed responses is substituted in
+const clientRuntime = `export type Fetcher = (input: string, init: RequestInit) => Promise<Response>;
+
+export type ClientOptions = {
+	baseUrl?: string;
+	fetch?: Fetcher;
+	headers?: Record<string, string>;
+};
+
+export class ApiError extends Error {
+	readonly status: number;
+	readonly body: %s;
+
+	constructor(status: number, body: %s) {
+		super(` + "`request failed with status ${status}`" + `);
+		this.name = "ApiError";
+		this.status = status;
+		this.body = body;
+	}
+}
+
+function toQuery(query?: object): string {
+	if (query === undefined || query === null) {
+		return "";
+	}
+
+	const params = new URLSearchParams();
+	for (const [key, value] of Object.entries(query)) {
+		if (value === undefined || value === null) {
+			continue;
+		}
+
+		for (const item of Array.isArray(value) ? value : [value]) {
+			params.append(key, typeof item === "object" ? JSON.stringify(item) : String(item));
+		}
+	}
+
+	const search = params.toString();
+	return search === "" ? "" : ` + "`?${search}`" + `;
+}
+
+async function parseBody(response: Response): Promise<unknown> {
+	const text = await response.text();
+	if (text === "") {
+		return undefined;
+	}
+
+	try {
+		return JSON.parse(text);
+	} catch {
+		return text;
+	}
+}
+
+async function request<T>(options: ClientOptions, method: string, path: string, query?: object, body?: unknown): Promise<T> {
+	const fetcher: Fetcher = options.fetch ?? ((input, init) => fetch(input, init));
+	const headers: Record<string, string> = { Accept: "application/json", ...options.headers };
+	if (body !== undefined) {
+		headers["Content-Type"] = "application/json";
+	}
+
+	const response = await fetcher((options.baseUrl ?? "") + path + toQuery(query), {
+		method,
+		headers,
+		body: body === undefined ? undefined : JSON.stringify(body),
+	});
+
+	const data = await parseBody(response);
+	if (!response.ok) {
+		throw new ApiError(response.status, data as %s);
+	}
+
+	return data as T;
+}`
+
+// clientFactory is the `createClient` function, the methods of the endpoints are substituted in
+const clientFactory = `export function createClient(options: ClientOptions = {}) {
+	const send = <T>(method: string, path: string, query?: object, body?: unknown): Promise<T> => request<T>(options, method, path, query, body);
+
+	return {
+%s
+	};
+}`
@@ -0,0 +1,145 @@
+package typescript_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.trulyao.dev/mirror/v2/endpoint"
+	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+type (
+	GetUserQuery struct {
+		Expand []string `json:"expand"`
+	}
+
+	ErrorResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+func Test_GenerateClient(t *testing.T) {
+	tests := []struct {
+		Description string
+		Config      *typescript.ClientConfig
+		Endpoints   []endpoint.Endpoint
+		Expect      []string // snippets the client must contain
+		WantErr     bool
+	}{
+		{
+			Description: "generate client",
+			Config:      typescript.NewClient(typescript.DefaultConfig()),
+			Endpoints: []endpoint.Endpoint{
+				{Method: "GET", Path: "/users/{id}", Request: reflect.TypeOf(GetUserQuery{}), Response: reflect.TypeOf(User{})},
+				{Name: "createUser", Method: "POST", Path: "/users", Request: reflect.TypeOf(User{}), Response: reflect.TypeOf(&User{})},
+				{Method: "GET", Path: "/users", Response: reflect.TypeOf([]UserProfile{})},
+				{Method: "DELETE", Path: "/files/{path...}"},
+			},
+			Expect: []string{
+				`import type { GetUserQuery, User, UserProfile } from "./generated";`,
+				"readonly body: unknown;",
+				"export function createClient(options: ClientOptions = {}) {",
+				"        getUsersById: (params: { id: string | number }, query: GetUserQuery): Promise<User> => send<User>(\"GET\", `/users/${encodeURIComponent(String(params[\"id\"]))}`, query),",
+				"        createUser: (body: User): Promise<User> => send<User>(\"POST\", \"/users\", undefined, body),",
+				"        getUsers: (): Promise<Array<UserProfile>> => send<Array<UserProfile>>(\"GET\", \"/users\"),",
+				"        deleteFilesByPath: (params: { path: string | number }): Promise<void> => send<void>(\"DELETE\", `/files/${encodeURI(String(params[\"path\"]))}`),",
+				"export type Client = ReturnType<typeof createClient>;",
+			},
+		},
+		{
+			Description: "generate client with error type and type naming",
+			Config:      typescript.NewClient(typescript.DefaultConfig().SetSuffix("DTO")).SetErrorType("ErrorResponse").SetTypesImport("@/types"),
+			Endpoints: []endpoint.Endpoint{
+				{Method: "GET", Path: "/users/{$}", Response: reflect.TypeOf(User{})},
+			},
+			Expect: []string{
+				`import type { ErrorResponseDTO, UserDTO } from "@/types";`,
+				"readonly body: ErrorResponseDTO;",
+				"        getUsers: (): Promise<UserDTO> => send<UserDTO>(\"GET\", \"/users/\"),",
+			},
+		},
+		{
+			Description: "generate client with duplicate names",
+			Config:      typescript.NewClient(typescript.DefaultConfig()),
+			Endpoints: []endpoint.Endpoint{
+				{Method: "GET", Path: "/users"},
+				{Method: "GET", Path: "/users/{$}"},
+			},
+			WantErr: true,
+		},
+		{
+			Description: "generate client with unknown error type",
+			Config:      typescript.NewClient(typescript.DefaultConfig()).SetErrorType("Missing"),
+			Endpoints:   []endpoint.Endpoint{{Method: "GET", Path: "/users"}},
+			WantErr:     true,
+		},
+		{
+			Description: "generate client without endpoints",
+			Config:      typescript.NewClient(typescript.DefaultConfig()),
+			WantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		p := parser.New()
+		if err := p.AddSources(reflect.TypeOf(User{}), reflect.TypeOf(UserProfile{}), reflect.TypeOf(GetUserQuery{}), reflect.TypeOf(ErrorResponse{})); err != nil {
+			t.Fatalf("[%s] failed to add sources: %v", test.Description, err)
+		}
+
+		gen := typescript.NewClientGenerator(test.Config)
+		if err := gen.SetParser(p); err != nil {
+			t.Fatalf("[%s] failed to set parser: %v", test.Description, err)
+		}
+		gen.SetEndpoints(test.Endpoints)
+
+		output, err := gen.GenerateAll()
+		if test.WantErr {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got nil", test.Description)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.Description, err)
+			continue
+		}
+
+		client := strings.Join(output, "\n\n")
+		for _, snippet := range test.Expect {
+			if !strings.Contains(client, snippet) {
+				t.Errorf("[%s] expected client to contain %q, got:\n%s", test.Description, snippet, client)
+			}
+		}
+	}
+}
+
+func Test_ClientHeader(t *testing.T) {
+	typesConfig := typescript.DefaultConfig().AddImport("./datetime", "DateTime")
+	typesConfig.AddCustomType("time.Time", "DateTime")
+
+	config := typescript.NewClient(typesConfig)
+
+	p := parser.New()
+	gen := typescript.NewClientGenerator(config)
+	if err := gen.SetParser(p); err != nil {
+		t.Fatalf("failed to set parser: %v", err)
+	}
+	gen.SetEndpoints([]endpoint.Endpoint{{Method: "GET", Path: "/events", Response: reflect.TypeOf([]time.Time{})}})
+
+	output, err := gen.GenerateAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The inlined response uses the custom type, so the client has to import it like the types file does
+	client := config.Header() + "\n" + strings.Join(output, "\n\n")
+	for _, snippet := range []string{`import type { DateTime } from "./datetime";`, "Promise<Array<DateTime>>"} {
+		if !strings.Contains(client, snippet) {
+			t.Errorf("expected client to contain %q, got:\n%s", snippet, client)
+		}
+	}
+}
@@ -2,13 +2,47 @@ package typescript
 
 import (
 	"errors"
+	"fmt"
 	"path"
+	"slices"
 	"strings"
 
 	"go.trulyao.dev/mirror/v2/config"
+	"go.trulyao.dev/mirror/v2/naming"
 	"go.trulyao.dev/mirror/v2/types"
 )
 
+// Int64Mode determines how 64-bit integers (`int64`, `uint64`, `int` and `uint` on 64-bit platforms) are represented in the generated types
+type Int64Mode int
+
+const (
+	// Int64AsNumber represents 64-bit integers as `number`, values larger than `Number.MAX_SAFE_INTEGER` will lose precision (default)
+	Int64AsNumber Int64Mode = iota
+
+	// Int64AsBigInt represents 64-bit integers as `bigint`
+	Int64AsBigInt
+
+	// Int64AsString represents 64-bit integers as `string`, this is what you want if your integers are encoded with the `json:",string"` option
+	Int64AsString
+)
+
+// OutputMode determines how the generated declarations are split across files
+type OutputMode int
+
+const (
+	// OutputSingleFile writes all declarations to a single file named after `FileName` (default)
+	OutputSingleFile OutputMode = iota
+
+	// OutputPerPackage writes the declarations of each Go package to a separate file (e.g. `models.ts`) along with an `index.ts` barrel
+	OutputPerPackage
+
+	// OutputPerType writes each declaration to a separate file (e.g. `user-profile.ts`) along with an `index.ts` barrel
+	OutputPerType
+)
+
+// DefaultMaxTupleLength is the length above which fixed-length arrays are generated as regular arrays instead of tuples (when MaxTupleLength is not set)
+const DefaultMaxTupleLength = 16
+
 // Config is the configuration for the typescript generator, it also implements the types.TargetInterface and is used to define a Typescript target
 type Config struct {
 	// The generator for the current instance
@@ -35,6 +69,45 @@ type Config struct {
 	// PreferUnknown will prefer `unknown` over `any`
 	PreferUnknown bool
 
+	// Readonly will generate deeply immutable types, i.e. `readonly` properties, `ReadonlyArray<T>` (or `readonly T[]`) and `Readonly<Record<K, V>>`, individual fields can be made readonly with the `mirror:"readonly"` tag instead
+	Readonly bool
+
+	// ArraysAsTuples will generate fixed-length arrays as tuples (e.g. `[3]float64` -> `[number, number, number]`) instead of regular arrays
+	ArraysAsTuples bool
+
+	// MaxTupleLength is the length above which fixed-length arrays are generated as regular arrays even when ArraysAsTuples is enabled, defaults to `DefaultMaxTupleLength`
+	MaxTupleLength int
+
+	// GenerateDefaults will generate a `default<Type>` constant with the default values of the fields of each struct that has any (e.g. `export const defaultConfig: Partial<Config> = { port: 8080 };`)
+	GenerateDefaults bool
+
+	// OmitContextParams will leave `context.Context` parameters out of function types, contexts are usually provided by the transport (e.g. an RPC layer) instead of the caller
+	OmitContextParams bool
+
+	// OmitErrorReturns will leave a trailing `error` return value out of function types, errors are usually thrown (or rejected) in typescript instead of being returned
+	OmitErrorReturns bool
+
+	// AsyncFunctions will wrap the return type of function types in a `Promise` (e.g. `(id: string) => Promise<User>`), this is useful for async RPC stubs
+	AsyncFunctions bool
+
+	// GenerateTypeGuards will generate an `is<Type>(value: unknown): value is <Type>` function for each type that checks the shape of a value at runtime (e.g. the response of an API call)
+	GenerateTypeGuards bool
+
+	// BrandedTypes will generate scalars declared as their own types (e.g. `type UserID string`) as branded types (e.g. `export type UserID = string & { readonly __brand: "UserID" };`) and reference them by name, so that values of different named types cannot be mixed up
+	BrandedTypes bool
+
+	// PreferInterface will generate structs as `export interface X { ... }` instead of `export type X = { ... }`, other types (and nullable structs) are still generated as type aliases
+	PreferInterface bool
+
+	// ExtendEmbeddedTypes will generate `interface X extends Embedded { ... }` for flattened embedded structs instead of copying their fields, this only applies when PreferInterface is enabled
+	ExtendEmbeddedTypes bool
+
+	// Int64Mode is how 64-bit integers should be represented (number, bigint or string), defaults to `number`
+	Int64Mode Int64Mode
+
+	// OutputMode determines if the declarations are written to a single file or split into multiple files (per package or per type), `FileName` is ignored when the output is split
+	OutputMode OutputMode
+
 	// IndentationType is the type of indentation to use (space or tab)
 	IndentationType config.Indentation
 
@@ -44,8 +117,20 @@ type Config struct {
 	// Prefix is the prefix to add to the generated types (e.g. type Person -> type MyPrefixPerson)
 	TypePrefix string
 
-	// TODO: implement custom types support
+	// TypeSuffix is the suffix to add to the generated types (e.g. type Person -> type PersonDTO)
+	TypeSuffix string
+
+	// TypeNaming is the naming strategy applied to type names (before the prefix and suffix are added), names are kept as they are by default
+	TypeNaming naming.Strategy
+
+	// FieldNaming is the naming strategy applied to field names that have not been set explicitly (with the `json` or `mirror` tags, or parser hooks), names are kept as they are by default
+	FieldNaming naming.Strategy
+
+	// customTypes maps fully-qualified Go types (e.g. `github.com/shopspring/decimal.Decimal`) to the typescript types they should be generated as
 	customTypes map[string]string
+
+	// imports maps the module to import from to the (type) names to import from it, these are added to the top of the generated file
+	imports map[string][]string
 }
 
 // DefaultConfig returns a new Config with default values
@@ -61,6 +146,7 @@ func DefaultConfig() *Config {
 		IndentationType:       config.IndentSpace,
 		IndentationCount:      4,
 		customTypes:           make(map[string]string),
+		imports:               make(map[string][]string),
 	}
 }
 
@@ -70,6 +156,7 @@ func New(filename, path string) *Config {
 		FileName:         filename,
 		OutputPath:       path,
 		customTypes:      make(map[string]string),
+		imports:          make(map[string][]string),
 		IndentationCount: 4,
 	}
 }
@@ -110,8 +197,25 @@ func (c *Config) Language() string { return "typescript" }
 // Extension returns the file extension
 func (c *Config) Extension() string { return "ts" }
 
-// Header returns the header text for the file
-func (c *Config) Header() string { return fileHeader }
+// Header returns the header text for the file, including the import statements for external types (if any)
+func (c *Config) Header() string {
+	if len(c.imports) == 0 {
+		return fileHeader
+	}
+
+	modules := make([]string, 0, len(c.imports))
+	for module := range c.imports {
+		modules = append(modules, module)
+	}
+	slices.Sort(modules)
+
+	var imports []string
+	for _, module := range modules {
+		imports = append(imports, fmt.Sprintf("import type { %s } from %q;", strings.Join(c.imports[module], ", "), module))
+	}
+
+	return fileHeader + "\n" + strings.Join(imports, "\n") + "\n"
+}
 
 // SetFileName sets the name of the file to write to
 func (c *Config) SetFileName(name string) *Config {
@@ -151,6 +255,94 @@ func (c *Config) SetPreferUnknown(value bool) *Config {
 	return c
 }
 
+// SetReadonly sets whether or not to generate deeply immutable types
+func (c *Config) SetReadonly(value bool) *Config {
+	c.Readonly = value
+	return c
+}
+
+// SetArraysAsTuples sets whether or not to generate fixed-length arrays as tuples
+func (c *Config) SetArraysAsTuples(value bool) *Config {
+	c.ArraysAsTuples = value
+	return c
+}
+
+// SetMaxTupleLength sets the length above which fixed-length arrays are generated as regular arrays instead of tuples
+func (c *Config) SetMaxTupleLength(value int) *Config {
+	c.MaxTupleLength = value
+	return c
+}
+
+// maxTupleLength returns the configured maximum tuple length or the default
+func (c *Config) maxTupleLength() int {
+	if c.MaxTupleLength <= 0 {
+		return DefaultMaxTupleLength
+	}
+
+	return c.MaxTupleLength
+}
+
+// SetGenerateDefaults sets whether or not to generate constants with the default values of structs
+func (c *Config) SetGenerateDefaults(value bool) *Config {
+	c.GenerateDefaults = value
+	return c
+}
+
+// SetGenerateTypeGuards sets whether or not to generate runtime type guards for every type
+func (c *Config) SetGenerateTypeGuards(value bool) *Config {
+	c.GenerateTypeGuards = value
+	return c
+}
+
+// SetOmitContextParams sets whether or not to leave `context.Context` parameters out of function types
+func (c *Config) SetOmitContextParams(value bool) *Config {
+	c.OmitContextParams = value
+	return c
+}
+
+// SetOmitErrorReturns sets whether or not to leave trailing `error` return values out of function types
+func (c *Config) SetOmitErrorReturns(value bool) *Config {
+	c.OmitErrorReturns = value
+	return c
+}
+
+// SetAsyncFunctions sets whether or not to wrap the return types of function types in a `Promise`
+func (c *Config) SetAsyncFunctions(value bool) *Config {
+	c.AsyncFunctions = value
+	return c
+}
+
+// SetBrandedTypes sets whether or not to generate named scalars as branded types
+func (c *Config) SetBrandedTypes(value bool) *Config {
+	c.BrandedTypes = value
+	return c
+}
+
+// SetPreferInterface sets whether or not to generate structs as interfaces instead of type aliases
+func (c *Config) SetPreferInterface(value bool) *Config {
+	c.PreferInterface = value
+	return c
+}
+
+// SetExtendEmbeddedTypes sets whether or not interfaces should extend their (flattened) embedded structs instead of copying their fields
+// Embedded types are only flattened when `FlattenEmbeddedTypes` is enabled in the parser
+func (c *Config) SetExtendEmbeddedTypes(value bool) *Config {
+	c.ExtendEmbeddedTypes = value
+	return c
+}
+
+// SetInt64Mode sets how 64-bit integers should be represented (number, bigint or string)
+func (c *Config) SetInt64Mode(value Int64Mode) *Config {
+	c.Int64Mode = value
+	return c
+}
+
+// SetOutputMode sets how the generated declarations are split across files (single file, per package or per type)
+func (c *Config) SetOutputMode(value OutputMode) *Config {
+	c.OutputMode = value
+	return c
+}
+
 // SetIndentationType sets the type of indentation to use (space or tab)
 func (c *Config) SetIndentationType(value config.Indentation) *Config {
 	c.IndentationType = value
@@ -169,17 +361,63 @@ func (c *Config) SetPrefix(value string) *Config {
 	return c
 }
 
+// SetSuffix sets the suffix to add to the generated types
+func (c *Config) SetSuffix(value string) *Config {
+	c.TypeSuffix = value
+	return c
+}
+
+// SetTypeNaming sets the naming strategy applied to type names (e.g. `naming.PascalCase`)
+func (c *Config) SetTypeNaming(strategy naming.Strategy) *Config {
+	c.TypeNaming = strategy
+	return c
+}
+
+// SetFieldNaming sets the naming strategy applied to field names without an explicit name (e.g. `naming.CamelCase`)
+func (c *Config) SetFieldNaming(strategy naming.Strategy) *Config {
+	c.FieldNaming = strategy
+	return c
+}
+
 // SetPreferArrayGeneric sets whether or not to prefer `Array<T>` over `T[]`
 func (c *Config) SetPreferArrayGeneric(value bool) *Config {
 	c.PreferArrayGeneric = value
 	return c
 }
 
-// AddCustomType adds a custom type to the config
+// AddCustomType maps a fully-qualified Go type (e.g. `github.com/google/uuid.UUID` or `time.Time`) to a typescript type (e.g. `string` or `UUID`)
+// The custom type is used everywhere the Go type appears; declarations, fields, list and map elements and function parameters
+// If the typescript type is not built-in, use `AddImport` to import it from another module
 func (c *Config) AddCustomType(name, value string) {
+	if c.customTypes == nil {
+		c.customTypes = make(map[string]string)
+	}
+
 	c.customTypes[name] = value
 }
 
+// CustomType returns the typescript type a fully-qualified Go type has been mapped to (if any)
+func (c *Config) CustomType(name string) (string, bool) {
+	value, ok := c.customTypes[name]
+	return value, ok
+}
+
+// AddImport adds an `import type { ...names } from "module"` statement to the top of the generated file, this is useful for external types used in custom types
+func (c *Config) AddImport(module string, names ...string) *Config {
+	if c.imports == nil {
+		c.imports = make(map[string][]string)
+	}
+
+	for _, name := range names {
+		if !slices.Contains(c.imports[module], name) {
+			c.imports[module] = append(c.imports[module], name)
+		}
+	}
+
+	slices.Sort(c.imports[module])
+	return c
+}
+
 // Generator returns a new Generator for the current language with the config
 func (c *Config) Generator() types.GeneratorInterface {
 	if c.generator == nil {
@@ -203,6 +441,22 @@ func (c *Config) Validate() error {
 		return errors.New("indentation count must be greater than or equal to 2")
 	}
 
+	if c.Int64Mode < Int64AsNumber || c.Int64Mode > Int64AsString {
+		return errors.New(
+			"invalid int64 mode, expected `Int64AsNumber`, `Int64AsBigInt` or `Int64AsString`",
+		)
+	}
+
+	if c.OutputMode < OutputSingleFile || c.OutputMode > OutputPerType {
+		return errors.New(
+			"invalid output mode, expected `OutputSingleFile`, `OutputPerPackage` or `OutputPerType`",
+		)
+	}
+
+	if c.MaxTupleLength < 0 {
+		return errors.New("max tuple length cannot be negative")
+	}
+
 	if c.IndentationType != config.IndentSpace && c.IndentationType != config.IndentTab {
 		return errors.New(
 			"invalid indentation type, expected `config.IndentSpace` or `config.IndentTab` ",
@@ -0,0 +1,247 @@
+package typescript
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/helper"
+	"go.trulyao.dev/mirror/v2/parser"
+	"go.trulyao.dev/mirror/v2/types"
+)
+
+const (
+	// barrelFileName is the name of the file that re-exports every other file when the output is split
+	barrelFileName = "index"
+
+	// sharedFileName is the name of the file for items that do not belong to any package (e.g. unnamed types) when the output is split per package
+	sharedFileName = "shared"
+)
+
+// module holds the declarations and imports of a single generated file
+type module struct {
+	declarations []string
+
+	// imports maps the (extension-less) file to import from to the names imported from it
+	imports map[string][]string
+
+	// valueImports maps the (extension-less) file to import from to the values (i.e. type guards) imported from it
+	valueImports map[string][]string
+}
+
+// GenerateFiles generates all the type definitions in the parser grouped into files based on the configured output mode
+// When the output is split, each file imports the types it references from the other files and an `index.ts` barrel re-exporting every file is added
+func (g *Generator) GenerateFiles() ([]types.File, error) {
+	if g.parser == nil {
+		return nil, errors.New("no parser provided")
+	}
+
+	if g.config.OutputMode == OutputSingleFile {
+		declarations, err := g.GenerateAll()
+		if err != nil {
+			return nil, err
+		}
+
+		return []types.File{
+			{Name: g.config.Name(), Content: g.config.Header() + "\n" + strings.Join(declarations, "\n\n")},
+		}, nil
+	}
+
+	var items []parser.Item
+	if err := g.parser.Iterate(func(item parser.Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Every item's file needs to be known ahead of time to resolve the imports, items are looked up by their fully-qualified names since types in different packages can share a name
+	itemFiles, err := g.fileNames(items)
+	if err != nil {
+		return nil, err
+	}
+
+	filesByName := make(map[string]string, len(items))
+	for idx, item := range items {
+		filesByName[parser.QualifiedName(item)] = itemFiles[idx]
+	}
+
+	g.references = make(map[string]parser.Item)
+	g.guardReferences = make(map[string]parser.Item)
+	defer func() { g.references, g.guardReferences = nil, nil }()
+
+	modules := make(map[string]*module)
+	for idx, item := range items {
+		clear(g.references)
+		clear(g.guardReferences)
+
+		declaration, err := g.GenerateItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		file := itemFiles[idx]
+		mod, ok := modules[file]
+		if !ok {
+			mod = &module{imports: make(map[string][]string), valueImports: make(map[string][]string)}
+			modules[file] = mod
+		}
+
+		mod.declarations = append(mod.declarations, declaration)
+
+		for name, reference := range g.references {
+			referenceFile, ok := filesByName[name]
+			if !ok || referenceFile == file {
+				continue
+			}
+
+			addImport(mod.imports, referenceFile, g.typeName(reference))
+		}
+
+		for name, reference := range g.guardReferences {
+			referenceFile, ok := filesByName[name]
+			if !ok || referenceFile == file {
+				continue
+			}
+
+			addImport(mod.valueImports, referenceFile, g.guardName(reference.Name()))
+		}
+	}
+
+	fileNames := make([]string, 0, len(modules))
+	for file := range modules {
+		fileNames = append(fileNames, file)
+	}
+	slices.Sort(fileNames)
+
+	files := make([]types.File, 0, len(fileNames)+1)
+	exports := make([]string, 0, len(fileNames))
+	for _, file := range fileNames {
+		mod := modules[file]
+
+		content := g.config.Header() + "\n"
+		if imports := mod.importStatements(file); imports != "" {
+			content += imports + "\n\n"
+		}
+		content += strings.Join(mod.declarations, "\n\n")
+
+		files = append(files, types.File{Name: file + ".ts", Content: content})
+		exports = append(exports, fmt.Sprintf("export * from %q;", relativeImport(barrelFileName, file)))
+	}
+
+	files = append(files, types.File{
+		Name:    barrelFileName + ".ts",
+		Content: g.config.Header() + "\n" + strings.Join(exports, "\n"),
+	})
+
+	return files, nil
+}
+
+// addImport records a name imported from a file if it has not been recorded yet
+func addImport(imports map[string][]string, source, name string) {
+	if !slices.Contains(imports[source], name) {
+		imports[source] = append(imports[source], name)
+	}
+}
+
+// importStatements returns the sorted `import type { ... } from "..."` (and `import { ... } from "..."` for type guards) statements for a file
+func (m *module) importStatements(file string) string {
+	return strings.Join(append(importStatements(file, m.imports, "import type"), importStatements(file, m.valueImports, "import")...), "\n")
+}
+
+// importStatements returns the sorted import statements (using the given keyword) for the names imported from each file
+func importStatements(file string, imports map[string][]string, keyword string) []string {
+	sources := make([]string, 0, len(imports))
+	for source := range imports {
+		sources = append(sources, source)
+	}
+	slices.Sort(sources)
+
+	statements := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names := slices.Clone(imports[source])
+		slices.Sort(names)
+
+		statements = append(statements, fmt.Sprintf("%s { %s } from %q;", keyword, strings.Join(names, ", "), relativeImport(file, source)))
+	}
+
+	return statements
+}
+
+// fileNames returns the (extension-less) file each item should be written to based on the output mode
+// Types that would be written to the same file when the output is split per type (e.g. same-named types from different packages, or `UserID` and `UserId`) are reported as an error since their declarations would clash
+func (g *Generator) fileNames(items []parser.Item) ([]string, error) {
+	names := make([]string, len(items))
+
+	if g.config.OutputMode == OutputPerType {
+		// File names are compared case-insensitively since some file systems are case-insensitive
+		owners := make(map[string]parser.Item, len(items))
+		for idx, item := range items {
+			names[idx] = helper.ToKebabCase(g.typeName(item))
+
+			key := strings.ToLower(names[idx])
+			if owner, exists := owners[key]; exists {
+				return nil, fmt.Errorf("types `%s` and `%s` would both be written to `%s.ts`, rename one of them or use another output mode", parser.QualifiedName(owner), parser.QualifiedName(item), names[idx])
+			}
+			owners[key] = item
+		}
+
+		return names, nil
+	}
+
+	// Packages are named relative to the common prefix of all the packages (e.g. `example.com/app/models` and `example.com/app/api` become `models` and `api`)
+	var prefix []string
+	for _, item := range items {
+		pkgPath := parser.PackagePath(item)
+		if pkgPath == "" {
+			continue
+		}
+
+		segments := strings.Split(pkgPath, "/")
+		if prefix == nil {
+			prefix = segments
+			continue
+		}
+
+		common := 0
+		for common < len(prefix) && common < len(segments) && prefix[common] == segments[common] {
+			common++
+		}
+		prefix = prefix[:common]
+	}
+
+	for idx, item := range items {
+		pkgPath := parser.PackagePath(item)
+		if pkgPath == "" {
+			names[idx] = sharedFileName
+			continue
+		}
+
+		segments := strings.Split(pkgPath, "/")
+		if len(segments) > len(prefix) {
+			names[idx] = strings.Join(segments[len(prefix):], "/")
+		} else {
+			names[idx] = path.Base(pkgPath)
+		}
+	}
+
+	return names, nil
+}
+
+// relativeImport returns the path to import a file from another file (e.g. `./user` or `../models/user`), both files are extension-less and relative to the output path
+func relativeImport(from, to string) string {
+	relativePath, err := filepath.Rel(path.Dir(from), to)
+	if err != nil {
+		return "./" + to
+	}
+
+	relativePath = filepath.ToSlash(relativePath)
+	if !strings.HasPrefix(relativePath, ".") {
+		relativePath = "./" + relativePath
+	}
+
+	return relativePath
+}
@@ -0,0 +1,214 @@
+package typescript_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+type (
+	UserProfile struct {
+		Bio string `json:"bio"`
+	}
+
+	User struct {
+		Name    string        `json:"name"`
+		Profile UserProfile   `json:"profile"`
+		Friends []UserProfile `json:"friends"`
+	}
+
+	Document struct {
+		Constraints meta.Constraints `json:"constraints"`
+	}
+)
+
+func Test_GenerateFiles(t *testing.T) {
+	tests := []struct {
+		Description string
+		Mode        typescript.OutputMode
+		Config      *typescript.Config
+		Sources     []reflect.Type
+		Expect      map[string][]string // file name -> snippets the file must contain
+	}{
+		{
+			Description: "generate single file",
+			Mode:        typescript.OutputSingleFile,
+			Sources:     []reflect.Type{reflect.TypeOf(UserProfile{}), reflect.TypeOf(User{})},
+			Expect: map[string][]string{
+				"generated.ts": {"export type UserProfile = {", "export type User = {"},
+			},
+		},
+		{
+			Description: "generate one file per type",
+			Mode:        typescript.OutputPerType,
+			Sources:     []reflect.Type{reflect.TypeOf(UserProfile{}), reflect.TypeOf(User{})},
+			Expect: map[string][]string{
+				"user-profile.ts": {"export type UserProfile = {"},
+				"user.ts":         {`import type { UserProfile } from "./user-profile";`, "export type User = {"},
+				"index.ts":        {`export * from "./user";`, `export * from "./user-profile";`},
+			},
+		},
+		{
+			Description: "generate one file per package",
+			Mode:        typescript.OutputPerPackage,
+			Sources:     []reflect.Type{reflect.TypeOf(Document{}), reflect.TypeOf(meta.Constraints{})},
+			Expect: map[string][]string{
+				"extractor/meta.ts":            {"export type Constraints = {"},
+				"generator/typescript_test.ts": {`import type { Constraints } from "../extractor/meta";`, "export type Document = {"},
+				"index.ts":                     {`export * from "./extractor/meta";`, `export * from "./generator/typescript_test";`},
+			},
+		},
+		{
+			Description: "generate one file per type with type guards",
+			Mode:        typescript.OutputPerType,
+			Config:      typescript.DefaultConfig().SetGenerateTypeGuards(true).SetSuffix("DTO"),
+			Sources:     []reflect.Type{reflect.TypeOf(UserProfile{}), reflect.TypeOf(User{})},
+			Expect: map[string][]string{
+				"user-profile-dto.ts": {"export function isUserProfileDTO(value: unknown): value is UserProfileDTO {"},
+				"user-dto.ts": {
+					`import type { UserProfileDTO } from "./user-profile-dto";`,
+					`import { isUserProfileDTO } from "./user-profile-dto";`,
+					`isUserProfileDTO(v["profile"])`,
+				},
+				"index.ts": {`export * from "./user-dto";`, `export * from "./user-profile-dto";`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		p := parser.New()
+		if err := p.AddSources(test.Sources...); err != nil {
+			t.Fatalf("[%s] failed to add sources: %v", test.Description, err)
+		}
+
+		cfg := test.Config
+		if cfg == nil {
+			cfg = typescript.DefaultConfig()
+		}
+
+		gen := typescript.NewGenerator(cfg.SetOutputMode(test.Mode))
+		if err := gen.SetParser(p); err != nil {
+			t.Fatalf("[%s] failed to set parser: %v", test.Description, err)
+		}
+
+		files, err := gen.GenerateFiles()
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.Description, err)
+			continue
+		}
+
+		if len(files) != len(test.Expect) {
+			t.Errorf("[%s] expected %d files, got %d", test.Description, len(test.Expect), len(files))
+		}
+
+		for _, file := range files {
+			snippets, ok := test.Expect[file.Name]
+			if !ok {
+				t.Errorf("[%s] unexpected file %q", test.Description, file.Name)
+				continue
+			}
+
+			for _, snippet := range snippets {
+				if !strings.Contains(file.Content, snippet) {
+					t.Errorf("[%s] expected %q to contain %q, got:\n%s", test.Description, file.Name, snippet, file.Content)
+				}
+			}
+		}
+	}
+}
+
+func Test_GenerateFilesSameNames(t *testing.T) {
+	var (
+		name       = parser.Field{ItemName: "name", BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}}
+		modelsUser = &parser.Struct{ItemName: "User", PkgPath: "example.com/app/models", Fields: []parser.Field{name}}
+		authUser   = &parser.Struct{ItemName: "User", PkgPath: "example.com/app/auth", Fields: []parser.Field{name}}
+		team       = &parser.Struct{ItemName: "Team", PkgPath: "example.com/app/api", Fields: []parser.Field{{ItemName: "owner", BaseItem: modelsUser}}}
+	)
+
+	// The user in the auth package is registered last, the team must still import the user from the models package
+	doc, err := ir.NewDocument(modelsUser, team, authUser)
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+
+	gen := typescript.NewGenerator(typescript.DefaultConfig().SetOutputMode(typescript.OutputPerPackage).SetGenerateTypeGuards(true))
+	if err = gen.SetParser(ir.NewParser(doc)); err != nil {
+		t.Fatalf("failed to set parser: %v", err)
+	}
+
+	files, err := gen.GenerateFiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, file := range files {
+		if file.Name != "api.ts" {
+			continue
+		}
+
+		for _, snippet := range []string{`import type { User } from "./models";`, `import { isUser } from "./models";`} {
+			if !strings.Contains(file.Content, snippet) {
+				t.Errorf("expected %q to contain %q, got:\n%s", file.Name, snippet, file.Content)
+			}
+		}
+
+		return
+	}
+
+	t.Errorf("expected an `api.ts` file to be generated")
+}
+
+func Test_GenerateFilesPerTypeCollisions(t *testing.T) {
+	name := parser.Field{ItemName: "name", BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}}
+
+	tests := []struct {
+		Description string
+		Items       []parser.Item
+		WantErr     bool
+	}{
+		{
+			Description: "same-named types from different packages",
+			Items: []parser.Item{
+				&parser.Struct{ItemName: "User", PkgPath: "example.com/app/models", Fields: []parser.Field{name}},
+				&parser.Struct{ItemName: "User", PkgPath: "example.com/app/auth", Fields: []parser.Field{name}},
+			},
+			WantErr: true,
+		},
+		{
+			Description: "names that only differ in case",
+			Items: []parser.Item{
+				&parser.Scalar{ItemName: "UserID", PkgPath: "example.com/app/models", ItemType: parser.TypeString},
+				&parser.Scalar{ItemName: "UserId", PkgPath: "example.com/app/models", ItemType: parser.TypeString},
+			},
+			WantErr: true,
+		},
+		{
+			Description: "distinct names",
+			Items: []parser.Item{
+				&parser.Struct{ItemName: "User", PkgPath: "example.com/app/models", Fields: []parser.Field{name}},
+				&parser.Struct{ItemName: "Account", PkgPath: "example.com/app/auth", Fields: []parser.Field{name}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		doc, err := ir.NewDocument(test.Items...)
+		if err != nil {
+			t.Fatalf("[%s] failed to create document: %v", test.Description, err)
+		}
+
+		gen := typescript.NewGenerator(typescript.DefaultConfig().SetOutputMode(typescript.OutputPerType))
+		if err = gen.SetParser(ir.NewParser(doc)); err != nil {
+			t.Fatalf("[%s] failed to set parser: %v", test.Description, err)
+		}
+
+		if _, err = gen.GenerateFiles(); (err != nil) != test.WantErr {
+			t.Errorf("[%s] expected error: %v, got %v", test.Description, test.WantErr, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package typescript
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"go.trulyao.dev/mirror/v2/config"
@@ -30,6 +31,15 @@ type Generator struct {
 
 	// nonStrict is a flag to determine if the generator should be non-strict
 	nonStrict bool
+
+	// readonlyDepth is the number of readonly fields the generator is currently in, everything inlined in a readonly field is readonly as well
+	readonlyDepth int
+
+	// references holds the types referenced (by name) by the item currently being generated keyed by their fully-qualified names, this is used to generate the imports between files
+	references map[string]parser.Item
+
+	// guardReferences holds the types whose type guards are called by the item currently being generated keyed by their fully-qualified names, these are imported as values (not types) between files
+	guardReferences map[string]parser.Item
 }
 
 // NewGenerator returns a new typescript generator instance with the provided config
@@ -68,13 +78,52 @@ func (g *Generator) SetParser(parser types.ParserInterface) error {
 
 // GenerateItem generates a single item passed to it
 func (g *Generator) GenerateItem(item parser.Item) (string, error) {
+	declaration, err := g.generateDeclaration(item)
+	if err != nil {
+		return "", err
+	}
+
+	// Default values are generated as a constant right after the declaration of the struct
+	if structItem, ok := item.(*parser.Struct); ok && g.config.GenerateDefaults {
+		if defaults := g.generateDefaults(structItem); defaults != "" {
+			declaration += "\n\n" + defaults
+		}
+	}
+
+	if g.config.GenerateTypeGuards {
+		guard, err := g.generateTypeGuard(item)
+		if err != nil {
+			return "", err
+		}
+
+		declaration += "\n\n" + guard
+	}
+
+	return declaration, nil
+}
+
+// generateDeclaration generates the type declaration of an item (i.e. a type alias or an interface)
+func (g *Generator) generateDeclaration(item parser.Item) (string, error) {
+	if structItem, ok := item.(*parser.Struct); ok && g.canBeInterface(structItem) {
+		return g.generateInterface(structItem)
+	}
+
 	var (
 		typeString = "export type %s = %s"
 		baseType   string
 		err        error
 	)
 
-	baseType, err = g.generateBaseType(item, nil)
+	// Enums and branded types are declared with their values or brand, referencing them here would make the type refer to itself
+	scalar, isScalar := item.(*parser.Scalar)
+	switch {
+	case isScalar && g.isEnum(scalar):
+		baseType, err = g.generateEnum(scalar)
+	case isScalar && g.isBranded(scalar):
+		baseType, err = g.generateBrand(scalar)
+	default:
+		baseType, err = g.generateBaseType(item, nil)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -83,12 +132,7 @@ func (g *Generator) GenerateItem(item parser.Item) (string, error) {
 		typeString = strings.TrimSpace(typeString) + ";"
 	}
 
-	typeName := item.Name()
-	if g.config.TypePrefix != "" {
-		typeName = g.config.TypePrefix + typeName
-	}
-
-	return fmt.Sprintf(typeString, typeName, baseType), nil
+	return fmt.Sprintf(typeString, g.typeName(item), baseType), nil
 }
 
 // GenerateItemType generate ONLY the type definition for an item (e.g. "string", "{ foo: Bar, ...}")
@@ -122,22 +166,10 @@ func (g *Generator) generateBaseType(
 		level = nestingLevel[0]
 	}
 
-	switch item := item.(type) {
-	case *parser.Scalar:
-		baseType, err = g.generateScalar(item)
-	case *parser.List:
-		baseType, err = g.generateList(item, level)
-	case *parser.Struct:
-		baseType, err = g.generateStruct(item, level)
-	case *parser.Map:
-		baseType, err = g.generateMap(item, level)
-	case *parser.Function:
-		baseType, err = g.generateFunction(item)
-	default:
-		return "", fmt.Errorf("unknown type: %T", item)
-	}
-
-	if err != nil {
+	// User-defined custom types take precedence over the built-in representation
+	if customType, ok := g.customType(item); ok {
+		baseType = customType
+	} else if baseType, err = g.generateKnownType(item, level); err != nil {
 		return "", err
 	}
 
@@ -164,6 +196,33 @@ func (g *Generator) generateBaseType(
 	return baseType, nil
 }
 
+// generateKnownType generates the type for the built-in item types without any nullability information
+func (g *Generator) generateKnownType(item parser.Item, level int) (string, error) {
+	var (
+		baseType string
+		err      error
+	)
+
+	switch item := item.(type) {
+	case *parser.Scalar:
+		baseType, err = g.generateScalar(item)
+	case *parser.List:
+		baseType, err = g.generateList(item, level)
+	case *parser.Struct:
+		baseType, err = g.generateStruct(item, level)
+	case *parser.Map:
+		baseType, err = g.generateMap(item, level)
+	case *parser.Function:
+		baseType, err = g.generateFunction(item)
+	case *parser.Union:
+		baseType, err = g.generateUnion(item, level)
+	default:
+		return "", fmt.Errorf("unknown type: %T", item)
+	}
+
+	return baseType, err
+}
+
 // GenerateAll generates all the type definitions in the parser
 // This method uses the parser's Iterate method to iterate over all the items in the parser without consuming them
 func (g *Generator) GenerateAll() ([]string, error) {
@@ -230,18 +289,125 @@ func (g *Generator) getScalarRepresentation(mirrorType parser.Type) string {
 	return typeValue
 }
 
-// generateScalar generates the typescript representation of a scalar type (string, number, boolean, etc)
+// generateScalar generates the typescript representation of a scalar type (string, number, boolean, etc), enums and branded types are referenced by name
 func (g *Generator) generateScalar(item *parser.Scalar) (string, error) {
+	if (g.isEnum(item) || g.isBranded(item)) && g.referenceExists(item.Name()) {
+		return g.reference(item), nil
+	}
+
+	return g.scalarType(item)
+}
+
+// scalarType returns the underlying typescript type of a scalar, ignoring branding
+func (g *Generator) scalarType(item *parser.Scalar) (string, error) {
 	typeValue := g.getScalarRepresentation(item.Type())
 	if typeValue == "" {
 		return "", fmt.Errorf("unknown scalar type: %s", item.Name())
 	}
 
+	// 64-bit integers cannot be safely represented as a `number` in Javascript, so we let the user decide how they want them represented
+	if item.IsLargeInteger() {
+		switch g.config.Int64Mode {
+		case Int64AsBigInt:
+			typeValue = "bigint"
+		case Int64AsString:
+			typeValue = "string"
+		}
+	}
+
 	return typeValue, nil
 }
 
+// isEnum checks if a scalar should be generated as an enum, i.e. its values have been registered and it has no user-defined custom type
+func (g *Generator) isEnum(item *parser.Scalar) bool {
+	if _, ok := g.customType(item); ok {
+		return false
+	}
+
+	return len(item.Enum) > 0
+}
+
+// isBranded checks if a scalar should be generated as a branded type, i.e. it is declared as its own type and has no user-defined custom type
+// Enums are never branded since they are already limited to their values
+func (g *Generator) isBranded(item *parser.Scalar) bool {
+	if _, ok := g.customType(item); ok {
+		return false
+	}
+
+	return g.config.BrandedTypes && item.Named && len(item.Enum) == 0
+}
+
+// generateEnum generates the declaration of an enum as a union of its values (e.g. `"active" | "inactive"`), including its nullability
+func (g *Generator) generateEnum(item *parser.Scalar) (string, error) {
+	values, err := g.enumLiterals(item)
+	if err != nil {
+		return "", err
+	}
+
+	return g.withNullability(item, strings.Join(values, " | ")), nil
+}
+
+// enumLiterals returns the values of an enum as typescript literals, 64-bit integers are written as bigints or strings depending on the Int64Mode
+func (g *Generator) enumLiterals(item *parser.Scalar) ([]string, error) {
+	typeValue, err := g.scalarType(item)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(item.Enum))
+	for _, value := range item.Enum {
+		// Values are JSON-encoded, so strings and numbers are valid typescript literals as they are
+		switch typeValue {
+		case "bigint":
+			value += "n"
+		case "string":
+			if item.IsInteger() {
+				value = strconv.Quote(value)
+			}
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// generateBrand generates the declaration of a branded type (e.g. `string & { readonly __brand: "UserID" }`), including its nullability
+func (g *Generator) generateBrand(item *parser.Scalar) (string, error) {
+	typeValue, err := g.scalarType(item)
+	if err != nil {
+		return "", err
+	}
+
+	return g.withNullability(item, typeValue+fmt.Sprintf(" & { readonly __brand: %q }", g.typeName(item))), nil
+}
+
+// withNullability adds `null` (or `undefined`) to the type of a nullable item
+func (g *Generator) withNullability(item parser.Item, typeValue string) string {
+	if !item.IsNullable() {
+		return typeValue
+	}
+
+	if g.config.PreferNullForNullable {
+		return typeValue + " | null"
+	}
+
+	return typeValue + " | undefined"
+}
+
 // generateStruct generates the typescript representation of a struct
 func (g *Generator) generateStruct(item *parser.Struct, nestingLevel int) (string, error) {
+	fields, err := g.generateFields(item, nestingLevel, nil)
+	if err != nil {
+		return "", err
+	}
+
+	typeString := "{\n%s\n" + strings.Repeat(g.indent, nestingLevel-1) + "}"
+	return fmt.Sprintf(typeString, strings.Join(fields, "\n")), nil
+}
+
+// generateFields generates the typescript representation of each field in a struct, fields promoted from the embedded structs in `omit` (keyed by their fully-qualified names) are left out
+func (g *Generator) generateFields(item *parser.Struct, nestingLevel int, omit map[string]struct{}) ([]string, error) {
 	var fields []string
 
 	for _, field := range item.Fields {
@@ -250,8 +416,15 @@ func (g *Generator) generateStruct(item *parser.Struct, nestingLevel int) (strin
 			continue
 		}
 
+		// Fields of extended (embedded) types are inherited, so they don't need to be repeated
+		if field.EmbeddedFrom != nil {
+			if _, ok := omit[parser.QualifiedName(field.EmbeddedFrom)]; ok {
+				continue
+			}
+		}
+
 		var (
-			fieldName       = field.ItemName
+			fieldName       = g.fieldName(field)
 			fieldStr        string
 			hasOptionalChar bool
 		)
@@ -263,18 +436,18 @@ func (g *Generator) generateStruct(item *parser.Struct, nestingLevel int) (strin
 
 		// If the field has no name, we can't generate a type for it
 		if field.ItemName == "" && field.Meta.Name == "" {
-			return "", fmt.Errorf(
+			return nil, fmt.Errorf(
 				"unable to find name for field `%s` in struct `%s`",
 				field.BaseItem.Name(),
 				item.Name(),
 			)
 		}
 
-		if field.Meta.Name != "" {
-			fieldName = field.Meta.Name
+		if g.isReadonly() || field.Meta.Readonly {
+			fieldStr += "readonly "
 		}
 
-		fieldStr += fieldName
+		fieldStr += propertyName(fieldName)
 
 		if field.Meta.Optional.IsTrue() {
 			fieldStr += "?"
@@ -297,18 +470,31 @@ func (g *Generator) generateStruct(item *parser.Struct, nestingLevel int) (strin
 				}
 			}
 		} else {
-			if !g.config.InlineObjects && field.BaseItem.Type() == parser.TypeStruct {
+			if g.isReference(field.BaseItem) {
 				// Ensure the referenced type exists before proceeding - this is only necessary if inline objects are disabled since we don't want to reference a type that doesn't exist
 				if !g.referenceExists(field.BaseItem.Name()) {
-					return "", fmt.Errorf("referenced type `%s` does not exist, you need to either enable inline objects or pass in the referenced type", field.BaseItem.Name())
+					return nil, fmt.Errorf("referenced type `%s` does not exist, you need to either enable inline objects or pass in the referenced type", field.BaseItem.Name())
 				}
 
-				fieldStr += field.BaseItem.Name()
+				// Referenced declarations are only immutable when everything is, so readonly fields wrap them instead
+				if field.Meta.Readonly && !g.isReadonly() {
+					fieldStr += "Readonly<" + g.reference(field.BaseItem) + ">"
+				} else {
+					fieldStr += g.reference(field.BaseItem)
+				}
 			} else {
-				// Generate the base type for the field
+				// Generate the base type for the field, everything inlined in a readonly field is readonly as well
+				if field.Meta.Readonly {
+					g.readonlyDepth++
+				}
+
 				generatedType, err := g.generateBaseType(field.BaseItem, &field.Meta, nestingLevel+1)
+				if field.Meta.Readonly {
+					g.readonlyDepth--
+				}
+
 				if err != nil {
-					return "", err
+					return nil, err
 				}
 
 				fieldStr += generatedType
@@ -325,8 +511,153 @@ func (g *Generator) generateStruct(item *parser.Struct, nestingLevel int) (strin
 		fields = append(fields, fieldStr)
 	}
 
-	typeString := "{\n%s\n" + strings.Repeat(g.indent, nestingLevel-1) + "}"
-	return fmt.Sprintf(typeString, strings.Join(fields, "\n")), nil
+	return fields, nil
+}
+
+// generateDefaults generates a constant with the default values of a struct's fields (e.g. `export const defaultConfig: Partial<Config> = { port: 8080 };`)
+// Nothing is generated if none of the fields have a default value
+func (g *Generator) generateDefaults(item *parser.Struct) string {
+	var (
+		values   []string
+		complete = true
+	)
+
+	for _, field := range item.Fields {
+		if field.Meta.Skip {
+			continue
+		}
+
+		if field.Meta.Default == "" {
+			complete = false
+			continue
+		}
+
+		values = append(values, fmt.Sprintf("%s%s: %s,", g.indent, propertyName(g.fieldName(field)), g.defaultValue(field)))
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	typeName := g.typeName(item)
+	if !complete {
+		typeName = "Partial<" + typeName + ">"
+	}
+
+	declaration := fmt.Sprintf("export const default%s: %s = {\n%s\n}", g.typeName(item), typeName, strings.Join(values, "\n"))
+	if g.config.InludeSemiColon {
+		declaration += ";"
+	}
+
+	return declaration
+}
+
+// defaultValue returns the typescript literal for the (JSON-encoded) default value of a field, JSON values are valid typescript literals except for 64-bit integers which depend on the configured `Int64Mode`
+// Literals are not assignable to branded types, so the defaults of branded scalars are cast to their type (e.g. `"root" as UserID`)
+func (g *Generator) defaultValue(field parser.Field) string {
+	value := field.Meta.Default
+
+	scalar, ok := field.BaseItem.(*parser.Scalar)
+	if !ok || value == "null" || field.Meta.Type != "" {
+		return value
+	}
+
+	if _, isCustomType := g.customType(scalar); isCustomType {
+		return value
+	}
+
+	if scalar.IsLargeInteger() {
+		switch g.config.Int64Mode {
+		case Int64AsBigInt:
+			value += "n"
+		case Int64AsString:
+			value = strconv.Quote(value)
+		}
+	}
+
+	if g.isBranded(scalar) && g.referenceExists(scalar.Name()) {
+		value += " as " + g.reference(scalar)
+	}
+
+	return value
+}
+
+// canBeInterface checks if a struct should be declared as an interface, nullable structs and structs mapped to custom types can only be expressed as type aliases
+func (g *Generator) canBeInterface(item *parser.Struct) bool {
+	if !g.config.PreferInterface || item.IsNullable() {
+		return false
+	}
+
+	_, hasCustomType := g.customType(item)
+	return !hasCustomType
+}
+
+// generateInterface generates an interface declaration for a struct (e.g. `export interface Foo extends Bar { ... }`)
+func (g *Generator) generateInterface(item *parser.Struct) (string, error) {
+	var (
+		declaration = "export interface " + g.typeName(item)
+		extended    map[string]struct{}
+	)
+
+	if g.config.ExtendEmbeddedTypes {
+		var (
+			names []string
+			err   error
+		)
+
+		if names, extended, err = g.embeddedTypes(item); err != nil {
+			return "", err
+		}
+
+		if len(names) > 0 {
+			declaration += " extends " + strings.Join(names, ", ")
+		}
+	}
+
+	fields, err := g.generateFields(item, 1, extended)
+	if err != nil {
+		return "", err
+	}
+
+	if len(fields) == 0 {
+		return declaration + " {}", nil
+	}
+
+	return declaration + " {\n" + strings.Join(fields, "\n") + "\n}", nil
+}
+
+// embeddedTypes returns the names of the embedded structs a struct's fields were promoted from (in order of appearance) along with their fully-qualified names
+// Structs are compared by name since parsers (e.g. the IR parser) may decode the same embedded struct once per promoted field
+// Embedded structs that are mapped to custom types cannot be extended, so their fields are kept as they are
+func (g *Generator) embeddedTypes(item *parser.Struct) ([]string, map[string]struct{}, error) {
+	var (
+		names    []string
+		embedded = make(map[string]struct{})
+	)
+
+	for _, field := range item.Fields {
+		if field.EmbeddedFrom == nil {
+			continue
+		}
+
+		key := parser.QualifiedName(field.EmbeddedFrom)
+		if _, ok := embedded[key]; ok {
+			continue
+		}
+
+		if _, ok := g.customType(field.EmbeddedFrom); ok {
+			continue
+		}
+
+		if !g.referenceExists(field.EmbeddedFrom.Name()) {
+			return nil, nil, fmt.Errorf("embedded type `%s` does not exist, you need to either pass in the embedded type or disable `ExtendEmbeddedTypes`", field.EmbeddedFrom.Name())
+		}
+
+		embedded[key] = struct{}{}
+		names = append(names, g.reference(field.EmbeddedFrom))
+	}
+
+	return names, embedded, nil
 }
 
 // generateList generates the typescript representation of a list type (array or slice in Go)
@@ -336,9 +667,14 @@ func (g *Generator) generateList(item *parser.List, nestingLevel int) (string, e
 		err        error
 	)
 
-	if g.config.PreferArrayGeneric {
+	switch {
+	case g.config.PreferArrayGeneric && g.isReadonly():
+		listString = "ReadonlyArray<%s>"
+	case g.config.PreferArrayGeneric:
 		listString = "Array<%s>"
-	} else {
+	case g.isReadonly():
+		listString = "readonly %s[]"
+	default:
 		listString = "%s[]"
 	}
 
@@ -346,24 +682,27 @@ func (g *Generator) generateList(item *parser.List, nestingLevel int) (string, e
 		return "", fmt.Errorf("no base item found for list type: `%s`", item.Name())
 	}
 
-	var baseType string
+	var (
+		baseType     string
+		isNullableEl bool
+	)
 
-	// Scalar types are expanded to their types (e.g. string, number, etc) by default
-	if item.BaseItem.IsScalar() {
-		if baseType, err = g.generateScalar(item.BaseItem.(*parser.Scalar)); err != nil {
+	// Scalar (and custom) types are expanded to their types (e.g. string, number, etc) by default
+	customType, isCustomType := g.customType(item.BaseItem)
+	if item.BaseItem.IsScalar() || isCustomType {
+		if isCustomType {
+			baseType = customType
+		} else if baseType, err = g.generateScalar(item.BaseItem.(*parser.Scalar)); err != nil {
 			return "", err
 		}
 
 		if item.BaseItem.IsNullable() {
+			isNullableEl = true
 			if g.config.PreferNullForNullable {
 				baseType = fmt.Sprintf("%s | null", baseType)
 			} else {
 				baseType = fmt.Sprintf("%s | undefined", baseType)
 			}
-
-			if !g.config.PreferArrayGeneric {
-				baseType = fmt.Sprintf("(%s)", baseType)
-			}
 		}
 	} else {
 		// Ensure the referenced type exists before proceeding
@@ -372,92 +711,332 @@ func (g *Generator) generateList(item *parser.List, nestingLevel int) (string, e
 		}
 
 		// If inline objects are enabled, generate the base type for the item
-		baseType = item.BaseItem.Name()
 		if g.config.InlineObjects {
 			if baseType, err = g.generateBaseType(item.BaseItem, nil, nestingLevel); err != nil {
 				return "", err
 			}
+		} else {
+			baseType = g.reference(item.BaseItem)
 		}
 	}
 
+	if g.isTuple(item) {
+		return g.generateTuple(baseType, item.Length), nil
+	}
+
+	if isNullableEl && !g.config.PreferArrayGeneric {
+		baseType = fmt.Sprintf("(%s)", baseType)
+	}
+
 	return fmt.Sprintf(listString, baseType), nil
 }
 
+// isTuple checks if a list should be generated as a tuple, i.e. it is a fixed-length array that is not too large to be written out
+func (g *Generator) isTuple(item *parser.List) bool {
+	return g.config.ArraysAsTuples && item.IsArray() && item.Length <= g.config.maxTupleLength()
+}
+
+// generateTuple generates a tuple with `length` elements of the same type (e.g. `[number, number, number]`)
+func (g *Generator) generateTuple(elemType string, length int) string {
+	elems := make([]string, length)
+	for i := range elems {
+		elems[i] = elemType
+	}
+
+	tuple := "[" + strings.Join(elems, ", ") + "]"
+	if g.isReadonly() {
+		tuple = "readonly " + tuple
+	}
+
+	return tuple
+}
+
 // generateMap generates the typescript representation of a map
 func (g *Generator) generateMap(item *parser.Map, nestingLevel int) (string, error) {
-	typeString := "Record<%s, %s>"
-
 	if item.Key == nil || item.Value == nil {
 		return "", fmt.Errorf("key or value is nil for map type: `%s`", item.Name())
 	}
 
 	var (
 		keyType, valueType string
+		isEnumKey          bool
 		err                error
 	)
 
-	if !item.Key.IsScalar() {
-		return "", fmt.Errorf("non-scalar map key (%s) is not supported", item.Key.Name())
-	}
+	if customType, ok := g.customType(item.Key); ok {
+		keyType = customType
+	} else {
+		if !item.Key.IsScalar() {
+			return "", fmt.Errorf("non-scalar map key (%s) is not supported", item.Key.Name())
+		}
+
+		key, ok := item.Key.(*parser.Scalar)
+		if !ok {
+			return "", fmt.Errorf("non-scalar map key (%s) is not supported", item.Key.Name())
+		}
 
-	key, ok := item.Key.(*parser.Scalar)
-	if !ok {
-		return "", fmt.Errorf("non-scalar map key (%s) is not supported", item.Key.Name())
+		if keyType, isEnumKey, err = g.mapKey(key); err != nil {
+			return "", err
+		}
 	}
 
-	if keyType, err = g.generateScalar(key); err != nil {
-		return "", err
+	// Object keys cannot be bigints in Typescript, and JSON object keys are always strings anyway
+	if keyType == "bigint" {
+		keyType = "string"
 	}
 
 	if valueType, err = g.generateBaseType(item.Value, nil, nestingLevel); err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf(typeString, keyType, valueType), nil
+	typeString := fmt.Sprintf("Record<%s, %s>", keyType, valueType)
+
+	// A record with enum keys requires every value of the enum to be present, but maps can have any number of them
+	if isEnumKey {
+		typeString = "Partial<" + typeString + ">"
+	}
+
+	if g.isReadonly() {
+		typeString = "Readonly<" + typeString + ">"
+	}
+
+	return typeString, nil
+}
+
+// mapKey returns the type of a map key the way `encoding/json` encodes it, object keys are always strings in JSON so integer keys are represented as strings
+// Keys of other types (e.g. booleans and floats) cannot be encoded, non-string keys implementing `encoding.TextMarshaler` (including integer types) are parsed as strings by the parser already
+func (g *Generator) mapKey(key *parser.Scalar) (string, bool, error) {
+	switch key.Type() {
+	case parser.TypeString:
+		keyType, err := g.generateScalar(key)
+		return keyType, g.isEnum(key) && keyType != "string", err
+
+	case parser.TypeInteger:
+		// Integer enums can be used as keys as they are, since numeric property names are the same as their string representations (unless they are bigints)
+		if g.isEnum(key) && g.referenceExists(key.Name()) {
+			if typeValue, err := g.scalarType(key); err != nil || typeValue != "bigint" {
+				return g.reference(key), true, err
+			}
+		}
+
+		return "string", false, nil
+
+	default:
+		return "", false, fmt.Errorf("map key (%s) of type `%s` is not supported, JSON object keys must be strings, integers or implement `encoding.TextMarshaler`", key.Name(), key.Type())
+	}
 }
 
 // generateFunction generates the typescript representation of a function
 func (g *Generator) generateFunction(item *parser.Function) (string, error) {
 	var (
 		parameterTypes []string
-		returnType     = "void"
+		returnTypes    []string
 		err            error
 	)
 
 	for idx, param := range item.Params {
+		// Contexts are provided by the transport (e.g. an RPC layer), not by the caller
+		if g.config.OmitContextParams && parser.IsContext(param) {
+			continue
+		}
+
 		var paramStr string
 
-		// Scalar types are always expanded to their types (e.g. string, number, etc) by default
-		if g.config.InlineObjects || param.IsScalar() {
+		// Scalar (and custom) types are always expanded to their types (e.g. string, number, etc) by default
+		if _, isCustomType := g.customType(param); g.config.InlineObjects || param.IsScalar() || isCustomType {
 			if paramStr, err = g.generateBaseType(param, nil); err != nil {
 				return "", err
 			}
+		} else if g.isReference(param) {
+			if paramStr, err = g.functionReference(param); err != nil {
+				return "", err
+			}
 		} else {
-			paramStr = param.Name()
+			paramStr = g.reference(param)
 		}
 
-		parameterTypes = append(parameterTypes, "arg"+fmt.Sprint(idx)+": "+paramStr)
+		name := item.ParamName(idx)
+		if name == "" {
+			name = "arg" + fmt.Sprint(len(parameterTypes))
+		}
+
+		parameterTypes = append(parameterTypes, name+": "+paramStr)
 	}
 
-	if len(item.Returns) > 1 {
-		return "", errors.New("multiple return values are not supported in typescript")
+	returns := item.Returns
+	if g.config.OmitErrorReturns && len(returns) > 0 && parser.IsError(returns[len(returns)-1]) {
+		returns = returns[:len(returns)-1]
 	}
 
-	if len(item.Returns) > 0 {
-		returnItem := item.Returns[0]
-		if returnType, err = g.generateBaseType(returnItem, nil); err != nil {
+	for _, returnItem := range returns {
+		// Returned objects are referenced by name like parameters, unless they are inlined
+		if g.isReference(returnItem) {
+			returnType, err := g.functionReference(returnItem)
+			if err != nil {
+				return "", err
+			}
+
+			returnTypes = append(returnTypes, returnType)
+			continue
+		}
+
+		returnType, err := g.generateBaseType(returnItem, nil)
+		if err != nil {
 			return "", err
 		}
 
-		// Surround the return type with parentheses if it's a function
-		if returnItem.Type() == parser.TypeFunction {
+		// Surround the return type with parentheses if it's a function (unless it is wrapped in a `Promise`)
+		if returnItem.Type() == parser.TypeFunction && (len(returns) > 1 || !g.config.AsyncFunctions) {
 			returnType = "(" + returnType + ")"
 		}
+
+		returnTypes = append(returnTypes, returnType)
+	}
+
+	// Multiple return values are returned as a tuple (e.g. `[User, boolean]`)
+	var returnType string
+	switch len(returnTypes) {
+	case 0:
+		returnType = "void"
+	case 1:
+		returnType = returnTypes[0]
+	default:
+		returnType = "[" + strings.Join(returnTypes, ", ") + "]"
+	}
+
+	if g.config.AsyncFunctions {
+		returnType = "Promise<" + returnType + ">"
 	}
 
 	return fmt.Sprintf("(%s) => %s", strings.Join(parameterTypes, ", "), returnType), nil
 }
 
+// functionReference returns the reference to an object used as a function parameter or return value, including its nullability (e.g. `User | null` for `*User`)
+func (g *Generator) functionReference(item parser.Item) (string, error) {
+	if !g.referenceExists(item.Name()) {
+		return "", fmt.Errorf("referenced type `%s` does not exist, you need to either enable inline objects or pass in the referenced type", item.Name())
+	}
+
+	return g.withNullability(item, g.reference(item)), nil
+}
+
+// generateUnion generates the typescript representation of a discriminated union, each variant is expanded to an object type with the discriminator field as its first property
+// For example: `{ type: "created"; id: string; } | { type: "deleted"; id: string; }`
+func (g *Generator) generateUnion(item *parser.Union, nestingLevel int) (string, error) {
+	if item.Discriminator == "" {
+		return "", fmt.Errorf("no discriminator found for union type: `%s`", item.Name())
+	}
+
+	if len(item.Variants) == 0 {
+		return "", fmt.Errorf("no variants found for union type: `%s`", item.Name())
+	}
+
+	variants := make([]string, 0, len(item.Variants))
+	for _, variant := range item.Variants {
+		variantStruct, ok := variant.Item.(*parser.Struct)
+		if !ok {
+			return "", fmt.Errorf("variant `%s` of union `%s` is not an object type", variant.Tag, item.Name())
+		}
+
+		discriminator := parser.Field{
+			ItemName: item.Discriminator,
+			BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+			Meta:     meta.Meta{Name: item.Discriminator, ExplicitName: true, Type: strconv.Quote(variant.Tag)},
+		}
+
+		// The discriminator always comes first, if the variant already has a field with the same name, the discriminator takes its place
+		fields := []parser.Field{discriminator}
+		for _, field := range variantStruct.Fields {
+			if field.Meta.Name == item.Discriminator || (field.Meta.Name == "" && field.ItemName == item.Discriminator) {
+				continue
+			}
+
+			fields = append(fields, field)
+		}
+
+		variantType, err := g.generateStruct(&parser.Struct{ItemName: variantStruct.Name(), Fields: fields}, nestingLevel)
+		if err != nil {
+			return "", err
+		}
+
+		variants = append(variants, variantType)
+	}
+
+	return strings.Join(variants, " | "), nil
+}
+
+// typeName returns the name an item is declared as (i.e. with the configured naming strategy, prefix and suffix)
+func (g *Generator) typeName(item parser.Item) string {
+	return g.declaredName(item.Name())
+}
+
+// declaredName returns the name a type with the given (Go) name is declared as
+func (g *Generator) declaredName(name string) string {
+	return g.config.TypePrefix + g.config.TypeNaming.Apply(name) + g.config.TypeSuffix
+}
+
+// fieldName returns the name of a field in the generated types
+func (g *Generator) fieldName(field parser.Field) string {
+	name := field.ItemName
+	if field.Meta.Name != "" {
+		name = field.Meta.Name
+	}
+
+	// Naming strategies only apply to names inferred from the Go fields, explicit names (from struct tags or hooks) are kept as they are
+	if !field.Meta.ExplicitName {
+		name = g.config.FieldNaming.Apply(name)
+	}
+
+	return name
+}
+
+// propertyName returns the name of a property as it should be written in an object type, names that are not valid identifiers (e.g. `first-name`) are quoted
+func propertyName(name string) string {
+	if meta.FieldNameRegex.MatchString(name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+// reference returns the name to reference an item by and records the reference so that it can be imported when the output is split across files
+func (g *Generator) reference(item parser.Item) string {
+	if g.references != nil {
+		g.references[parser.QualifiedName(item)] = item
+	}
+
+	return g.typeName(item)
+}
+
+// isReadonly checks if the type currently being generated should be immutable
+func (g *Generator) isReadonly() bool {
+	return g.config.Readonly || g.readonlyDepth > 0
+}
+
+// customType returns the user-defined typescript type for an item (looked up by the item's fully-qualified Go type) if any
+func (g *Generator) customType(item parser.Item) (string, bool) {
+	name := parser.QualifiedName(item)
+	if name == "" {
+		return "", false
+	}
+
+	return g.config.CustomType(name)
+}
+
+// isReference checks if an item should be referenced by name instead of being inlined
+func (g *Generator) isReference(item parser.Item) bool {
+	if _, ok := g.customType(item); ok {
+		return false
+	}
+
+	return !g.config.InlineObjects && isObjectType(item)
+}
+
+// isObjectType checks if an item is represented as an object (and can therefore be referenced by name instead of being inlined)
+func isObjectType(item parser.Item) bool {
+	return item.Type() == parser.TypeStruct || item.Type() == parser.TypeUnion
+}
+
 // referenceExists() checks if the type being referenced exists in the parser, especially for non-inlined objects
 func (g *Generator) referenceExists(name string) bool {
 	if g.nonStrict {
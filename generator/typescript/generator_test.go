@@ -1,11 +1,13 @@
 package typescript_test
 
 import (
+	"reflect"
 	"testing"
 
 	"go.trulyao.dev/mirror/v2/config"
 	"go.trulyao.dev/mirror/v2/extractor/meta"
 	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/naming"
 	"go.trulyao.dev/mirror/v2/parser"
 )
 
@@ -63,6 +65,47 @@ func Test_GenerateScalar(t *testing.T) {
 			Expect: "export type NullableInt = number | undefined;",
 			Config: config,
 		},
+		{
+			Description: "generate 64-bit integer as number",
+			Src: &parser.Scalar{
+				ItemName: "Snowflake",
+				ItemType: parser.TypeInteger,
+				BitSize:  64,
+			},
+			Expect: "export type Snowflake = number;",
+			Config: config,
+		},
+		{
+			Description: "generate 64-bit integer as bigint",
+			Src: &parser.Scalar{
+				ItemName: "Snowflake",
+				ItemType: parser.TypeInteger,
+				BitSize:  64,
+				Unsigned: true,
+			},
+			Expect: "export type Snowflake = bigint;",
+			Config: typescript.Config{InludeSemiColon: true, Int64Mode: typescript.Int64AsBigInt},
+		},
+		{
+			Description: "generate 64-bit integer as string",
+			Src: &parser.Scalar{
+				ItemName: "Snowflake",
+				ItemType: parser.TypeInteger,
+				BitSize:  64,
+			},
+			Expect: "export type Snowflake = string;",
+			Config: typescript.Config{InludeSemiColon: true, Int64Mode: typescript.Int64AsString},
+		},
+		{
+			Description: "generate 32-bit integer with bigint mode",
+			Src: &parser.Scalar{
+				ItemName: "Small",
+				ItemType: parser.TypeInteger,
+				BitSize:  32,
+			},
+			Expect: "export type Small = number;",
+			Config: typescript.Config{InludeSemiColon: true, Int64Mode: typescript.Int64AsBigInt},
+		},
 		{
 			Description: "generate any",
 			Src: &parser.Scalar{
@@ -609,7 +652,7 @@ func Test_GenerateFunc(t *testing.T) {
 		},
 
 		{
-			Description: "generate function with multiple params and multiple returns",
+			Description: "generate function with multiple params and multiple returns as a tuple",
 			Src: &parser.Function{
 				ItemName: "MultiFunc",
 				Params: []parser.Item{
@@ -621,9 +664,8 @@ func Test_GenerateFunc(t *testing.T) {
 					&parser.Scalar{ItemName: "boolean", ItemType: parser.TypeBoolean},
 				},
 			},
-			Expect:  "",
-			WantErr: true,
-			Config:  typescript.Config{InludeSemiColon: true},
+			Expect: "export type MultiFunc = (arg0: string, arg1: number) => [string, boolean];",
+			Config: typescript.Config{InludeSemiColon: true},
 		},
 
 		{
@@ -734,6 +776,830 @@ func Test_GenerateFunc(t *testing.T) {
 	runTests(t, tests)
 }
 
+func Test_GenerateFuncReferences(t *testing.T) {
+	p := parser.New()
+	if err := p.AddSource(reflect.TypeOf(UserProfile{})); err != nil {
+		t.Fatalf("failed to add source: %v", err)
+	}
+
+	profile, err := p.Parse(reflect.TypeOf(&UserProfile{}))
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	missing := &parser.Struct{ItemName: "Missing", Fields: []parser.Field{{ItemName: "id", BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}}}}
+
+	tests := []Test{
+		{
+			Description: "generate function with nullable referenced params and returns",
+			Src:         &parser.Function{ItemName: "LoadProfile", Params: []parser.Item{profile}, Returns: []parser.Item{profile}},
+			Expect:      "export type LoadProfile = (arg0: UserProfile | null) => UserProfile | null;",
+			Config:      *typescript.DefaultConfig().SetPreferNullForNullable(true),
+		},
+		{
+			Description: "generate function returning a type that does not exist",
+			Src:         &parser.Function{ItemName: "LoadMissing", Returns: []parser.Item{missing}},
+			Config:      *typescript.DefaultConfig(),
+			WantErr:     true,
+		},
+		{
+			Description: "generate function taking a type that does not exist",
+			Src:         &parser.Function{ItemName: "SaveMissing", Params: []parser.Item{missing}},
+			Config:      *typescript.DefaultConfig(),
+			WantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		gen := typescript.NewGenerator(&test.Config)
+		if err := gen.SetParser(p); err != nil {
+			t.Fatalf("[%s] failed to set parser: %v", test.Description, err)
+		}
+
+		got, err := gen.GenerateItem(test.Src)
+		if (err != nil) != test.WantErr {
+			t.Errorf("[%s] expected error: %v, got %v", test.Description, test.WantErr, err)
+			continue
+		}
+
+		if got != test.Expect {
+			t.Errorf("[%s] expected %q, got %q", test.Description, test.Expect, got)
+		}
+	}
+}
+
+func Test_GenerateUnion(t *testing.T) {
+	idField := parser.Field{
+		ItemName: "id",
+		BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+		Meta:     meta.Meta{Name: "id"},
+	}
+
+	union := &parser.Union{
+		ItemName:      "Event",
+		Discriminator: "type",
+		Variants: []parser.UnionVariant{
+			{
+				Tag:  "created",
+				Item: &parser.Struct{ItemName: "EventCreated", Fields: []parser.Field{idField}},
+			},
+			{
+				Tag: "deleted",
+				Item: &parser.Struct{
+					ItemName: "EventDeleted",
+					Fields: []parser.Field{
+						// This should be replaced by the discriminator
+						{
+							ItemName: "type",
+							BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+							Meta:     meta.Meta{Name: "type"},
+						},
+						idField,
+					},
+				},
+			},
+		},
+	}
+
+	config := typescript.Config{
+		InludeSemiColon:       true,
+		IndentationType:       config.IndentSpace,
+		IndentationCount:      4,
+		PreferNullForNullable: true,
+	}
+
+	tests := []Test{
+		{
+			Description: "generate discriminated union",
+			Src:         union,
+			Expect:      "export type Event = {\n    type: \"created\";\n    id: string;\n} | {\n    type: \"deleted\";\n    id: string;\n};",
+			Config:      config,
+		},
+		{
+			Description: "generate nullable discriminated union",
+			Src:         &parser.Union{ItemName: "Event", Discriminator: "type", Variants: union.Variants[:1], Nullable: true},
+			Expect:      "export type Event = {\n    type: \"created\";\n    id: string;\n} | null;",
+			Config:      config,
+		},
+		{
+			Description: "generate struct referencing a union",
+			Src: &parser.Struct{
+				ItemName: "Envelope",
+				Fields:   []parser.Field{{ItemName: "event", BaseItem: union, Meta: meta.Meta{Name: "event"}}},
+			},
+			Expect: "export type Envelope = {\n    event: Event;\n};",
+			Config: config,
+		},
+		{
+			Description: "generate union without discriminator",
+			Src:         &parser.Union{ItemName: "Event", Variants: union.Variants},
+			Config:      config,
+			WantErr:     true,
+		},
+		{
+			Description: "generate union with non-object variant",
+			Src: &parser.Union{
+				ItemName:      "Event",
+				Discriminator: "type",
+				Variants:      []parser.UnionVariant{{Tag: "raw", Item: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}}},
+			},
+			Config:  config,
+			WantErr: true,
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateCustomTypes(t *testing.T) {
+	decimal := &parser.Struct{ItemName: "Decimal", PkgPath: "github.com/shopspring/decimal", Fields: []parser.Field{}}
+	uuid := &parser.List{
+		ItemName: "UUID",
+		PkgPath:  "github.com/google/uuid",
+		BaseItem: &parser.Scalar{ItemName: "uint8", ItemType: parser.TypeInteger, BitSize: 8, Unsigned: true},
+		Length:   16,
+	}
+
+	customConfig := typescript.DefaultConfig()
+	customConfig.AddCustomType("github.com/shopspring/decimal.Decimal", "string")
+	customConfig.AddCustomType("github.com/google/uuid.UUID", "UUID")
+
+	tests := []Test{
+		{
+			Description: "generate declaration for custom type",
+			Src:         decimal,
+			Expect:      "export type Decimal = string;",
+			Config:      *customConfig,
+		},
+		{
+			Description: "generate struct with custom type fields",
+			Src: &parser.Struct{
+				ItemName: "Invoice",
+				Fields: []parser.Field{
+					{ItemName: "ID", BaseItem: uuid, Meta: meta.Meta{Name: "id"}},
+					{ItemName: "Amount", BaseItem: decimal, Meta: meta.Meta{Name: "amount"}},
+				},
+			},
+			Expect: "export type Invoice = {\n    id: UUID;\n    amount: string;\n};",
+			Config: *customConfig,
+		},
+		{
+			Description: "generate list of custom types",
+			Src:         &parser.List{ItemName: "Amounts", BaseItem: decimal},
+			Expect:      "export type Amounts = Array<string>;",
+			Config:      *customConfig,
+		},
+		{
+			Description: "generate map with custom key and value types",
+			Src:         &parser.Map{ItemName: "Balances", Key: uuid, Value: decimal},
+			Expect:      "export type Balances = Record<UUID, string>;",
+			Config:      *customConfig,
+		},
+		{
+			Description: "generate function with custom type params",
+			Src: &parser.Function{
+				ItemName: "Transfer",
+				Params:   []parser.Item{uuid, decimal},
+				Returns:  []parser.Item{decimal},
+			},
+			Expect: "export type Transfer = (arg0: UUID, arg1: string) => string;",
+			Config: *customConfig,
+		},
+		{
+			Description: "generate struct without custom types configured",
+			Src: &parser.Struct{
+				ItemName: "Invoice",
+				Fields:   []parser.Field{{ItemName: "Amount", BaseItem: decimal, Meta: meta.Meta{Name: "amount"}}},
+			},
+			Expect: "export type Invoice = {\n    amount: Decimal;\n};",
+			Config: *typescript.DefaultConfig(),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateInterface(t *testing.T) {
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	base := &parser.Struct{
+		ItemName: "Base",
+		Fields:   []parser.Field{{ItemName: "id", BaseItem: str, Meta: meta.Meta{Name: "id"}}},
+	}
+	user := &parser.Struct{
+		ItemName: "User",
+		Fields: []parser.Field{
+			{ItemName: "id", BaseItem: str, Meta: meta.Meta{Name: "id"}, EmbeddedFrom: base},
+			{ItemName: "name", BaseItem: str, Meta: meta.Meta{Name: "name"}},
+		},
+	}
+
+	interfaceConfig := typescript.DefaultConfig().SetPreferInterface(true)
+	extendsConfig := typescript.DefaultConfig().SetPreferInterface(true).SetExtendEmbeddedTypes(true)
+
+	tests := []Test{
+		{
+			Description: "generate struct as interface",
+			Src:         base,
+			Expect:      "export interface Base {\n    id: string;\n}",
+			Config:      *interfaceConfig,
+		},
+		{
+			Description: "generate struct with embedded fields as interface without extends",
+			Src:         user,
+			Expect:      "export interface User {\n    id: string;\n    name: string;\n}",
+			Config:      *interfaceConfig,
+		},
+		{
+			Description: "generate struct with embedded fields as interface with extends",
+			Src:         user,
+			Expect:      "export interface User extends Base {\n    name: string;\n}",
+			Config:      *extendsConfig,
+		},
+		{
+			Description: "generate interface with only embedded fields",
+			Src: &parser.Struct{
+				ItemName: "Admin",
+				Fields:   []parser.Field{{ItemName: "id", BaseItem: str, Meta: meta.Meta{Name: "id"}, EmbeddedFrom: base}},
+			},
+			Expect: "export interface Admin extends Base {}",
+			Config: *extendsConfig,
+		},
+		{
+			Description: "generate nullable struct as type alias",
+			Src:         &parser.Struct{ItemName: "Base", Fields: base.Fields, Nullable: true},
+			Expect:      "export type Base = {\n    id: string;\n} | null;",
+			Config:      *interfaceConfig,
+		},
+		{
+			Description: "generate non-struct as type alias",
+			Src:         &parser.List{ItemName: "Users", BaseItem: user, Length: parser.EmptyLength},
+			Expect:      "export type Users = Array<User>;",
+			Config:      *interfaceConfig,
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateReadonly(t *testing.T) {
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	tags := &parser.List{ItemName: "", BaseItem: str, Length: parser.EmptyLength}
+	labels := &parser.Map{ItemName: "", Key: str, Value: str}
+	user := &parser.Struct{ItemName: "User", Fields: []parser.Field{{ItemName: "name", BaseItem: str, Meta: meta.Meta{Name: "name"}}}}
+
+	post := &parser.Struct{
+		ItemName: "Post",
+		Fields: []parser.Field{
+			{ItemName: "id", BaseItem: str, Meta: meta.Meta{Name: "id", Readonly: true}},
+			{ItemName: "tags", BaseItem: tags, Meta: meta.Meta{Name: "tags"}},
+			{ItemName: "labels", BaseItem: labels, Meta: meta.Meta{Name: "labels"}},
+			{ItemName: "author", BaseItem: user, Meta: meta.Meta{Name: "author"}},
+		},
+	}
+
+	readonlyFields := &parser.Struct{
+		ItemName: "Post",
+		Fields: []parser.Field{
+			{ItemName: "tags", BaseItem: tags, Meta: meta.Meta{Name: "tags", Readonly: true}},
+			{ItemName: "labels", BaseItem: labels, Meta: meta.Meta{Name: "labels", Readonly: true}},
+			{ItemName: "author", BaseItem: user, Meta: meta.Meta{Name: "author", Readonly: true}},
+		},
+	}
+
+	tests := []Test{
+		{
+			Description: "generate readonly field",
+			Src:         post,
+			Expect:      "export type Post = {\n    readonly id: string;\n    tags: Array<string>;\n    labels: Record<string, string>;\n    author: User;\n};",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate readonly list, map and referenced fields",
+			Src:         readonlyFields,
+			Expect:      "export type Post = {\n    readonly tags: ReadonlyArray<string>;\n    readonly labels: Readonly<Record<string, string>>;\n    readonly author: Readonly<User>;\n};",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate deeply readonly struct",
+			Src:         post,
+			Expect:      "export type Post = {\n    readonly id: string;\n    readonly tags: ReadonlyArray<string>;\n    readonly labels: Readonly<Record<string, string>>;\n    readonly author: User;\n};",
+			Config:      *typescript.DefaultConfig().SetReadonly(true),
+		},
+		{
+			Description: "generate deeply readonly struct with inline objects and array syntax",
+			Src:         &parser.Struct{ItemName: "Post", Fields: []parser.Field{{ItemName: "authors", BaseItem: &parser.List{BaseItem: user, Length: parser.EmptyLength}, Meta: meta.Meta{Name: "authors"}}}},
+			Expect:      "export type Post = {\n    readonly authors: readonly {\n        readonly name: string;\n    }[];\n};",
+			Config:      *typescript.DefaultConfig().SetReadonly(true).SetInlineObjects(true).SetPreferArrayGeneric(false),
+		},
+		{
+			Description: "generate readonly list declaration",
+			Src:         &parser.List{ItemName: "Tags", BaseItem: str, Length: parser.EmptyLength},
+			Expect:      "export type Tags = readonly string[];",
+			Config:      *typescript.DefaultConfig().SetReadonly(true).SetPreferArrayGeneric(false),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateTuples(t *testing.T) {
+	float := &parser.Scalar{ItemName: "float64", ItemType: parser.TypeFloat, BitSize: 64}
+	point := &parser.List{ItemName: "Point", BaseItem: float, Length: 3}
+	tuples := typescript.DefaultConfig().SetArraysAsTuples(true)
+
+	tests := []Test{
+		{
+			Description: "generate fixed-length array as array without tuples enabled",
+			Src:         point,
+			Expect:      "export type Point = Array<number>;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate fixed-length array as tuple",
+			Src:         point,
+			Expect:      "export type Point = [number, number, number];",
+			Config:      *tuples,
+		},
+		{
+			Description: "generate empty array as empty tuple",
+			Src:         &parser.List{ItemName: "Empty", BaseItem: float, Length: 0},
+			Expect:      "export type Empty = [];",
+			Config:      *tuples,
+		},
+		{
+			Description: "generate slice as array with tuples enabled",
+			Src:         &parser.List{ItemName: "Points", BaseItem: float, Length: parser.EmptyLength},
+			Expect:      "export type Points = Array<number>;",
+			Config:      *tuples,
+		},
+		{
+			Description: "generate tuple of nullable elements",
+			Src:         &parser.List{ItemName: "Pair", BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true}, Length: 2},
+			Expect:      "export type Pair = [string | null, string | null];",
+			Config:      *tuples,
+		},
+		{
+			Description: "generate readonly tuple",
+			Src:         point,
+			Expect:      "export type Point = readonly [number, number, number];",
+			Config:      *typescript.DefaultConfig().SetArraysAsTuples(true).SetReadonly(true),
+		},
+		{
+			Description: "generate large fixed-length array as array",
+			Src:         &parser.List{ItemName: "Matrix", BaseItem: float, Length: 3},
+			Expect:      "export type Matrix = number[];",
+			Config:      *typescript.DefaultConfig().SetArraysAsTuples(true).SetMaxTupleLength(2).SetPreferArrayGeneric(false),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateNaming(t *testing.T) {
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	profile := &parser.Struct{
+		ItemName: "user_profile",
+		Fields: []parser.Field{
+			{ItemName: "FirstName", BaseItem: str, Meta: meta.Meta{OriginalName: "FirstName", Name: "FirstName"}},
+			{ItemName: "last_name", BaseItem: str, Meta: meta.Meta{OriginalName: "LastName", Name: "last_name", ExplicitName: true}},
+		},
+	}
+
+	tests := []Test{
+		{
+			Description: "generate with default naming",
+			Src:         profile,
+			Expect:      "export type user_profile = {\n    FirstName: string;\n    last_name: string;\n};",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate with camel case fields",
+			Src:         profile,
+			Expect:      "export type user_profile = {\n    firstName: string;\n    last_name: string;\n};",
+			Config:      *typescript.DefaultConfig().SetFieldNaming(naming.CamelCase),
+		},
+		{
+			Description: "generate with kebab case fields",
+			Src:         profile,
+			Expect:      "export type user_profile = {\n    \"first-name\": string;\n    last_name: string;\n};",
+			Config:      *typescript.DefaultConfig().SetFieldNaming(naming.KebabCase),
+		},
+		{
+			Description: "generate with type naming, prefix and suffix",
+			Src:         profile,
+			Expect:      "export type ApiUserProfileDTO = {\n    FirstName: string;\n    last_name: string;\n};",
+			Config:      *typescript.DefaultConfig().SetTypeNaming(naming.PascalCase).SetPrefix("Api").SetSuffix("DTO"),
+		},
+		{
+			Description: "generate reference with type naming",
+			Src:         &parser.List{ItemName: "Profiles", BaseItem: profile, Length: parser.EmptyLength},
+			Expect:      "export type ProfilesDTO = Array<UserProfileDTO>;",
+			Config:      *typescript.DefaultConfig().SetTypeNaming(naming.PascalCase).SetSuffix("DTO"),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateDefaults(t *testing.T) {
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	config := &parser.Struct{
+		ItemName: "Config",
+		Fields: []parser.Field{
+			{ItemName: "host", BaseItem: str, Meta: meta.Meta{Name: "host", Default: `"localhost"`}},
+			{ItemName: "port", BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, BitSize: 64}, Meta: meta.Meta{Name: "port", Default: "8080"}},
+			{ItemName: "tags", BaseItem: &parser.List{BaseItem: str, Length: parser.EmptyLength}, Meta: meta.Meta{Name: "tags", Default: `["a","b"]`}},
+		},
+	}
+	partial := &parser.Struct{
+		ItemName: "Server",
+		Fields: []parser.Field{
+			{ItemName: "host", BaseItem: str, Meta: meta.Meta{Name: "host", Default: `"localhost"`}},
+			{ItemName: "name", BaseItem: str, Meta: meta.Meta{Name: "name"}},
+			{ItemName: "secret", BaseItem: str, Meta: meta.Meta{Name: "secret", Skip: true, Default: `"x"`}},
+		},
+	}
+
+	declaration := "export type Config = {\n    host: string;\n    port: number;\n    tags: Array<string>;\n};"
+
+	tests := []Test{
+		{
+			Description: "generate struct without defaults enabled",
+			Src:         config,
+			Expect:      declaration,
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate struct with defaults",
+			Src:         config,
+			Expect:      declaration + "\n\nexport const defaultConfig: Config = {\n    host: \"localhost\",\n    port: 8080,\n    tags: [\"a\",\"b\"],\n};",
+			Config:      *typescript.DefaultConfig().SetGenerateDefaults(true),
+		},
+		{
+			Description: "generate struct with bigint defaults",
+			Src:         config,
+			Expect:      "export type Config = {\n    host: string;\n    port: bigint;\n    tags: Array<string>;\n};\n\nexport const defaultConfig: Config = {\n    host: \"localhost\",\n    port: 8080n,\n    tags: [\"a\",\"b\"],\n};",
+			Config:      *typescript.DefaultConfig().SetGenerateDefaults(true).SetInt64Mode(typescript.Int64AsBigInt),
+		},
+		{
+			Description: "generate struct with partial defaults",
+			Src:         partial,
+			Expect:      "export type Server = {\n    host: string;\n    name: string;\n};\n\nexport const defaultServer: Partial<Server> = {\n    host: \"localhost\",\n};",
+			Config:      *typescript.DefaultConfig().SetGenerateDefaults(true),
+		},
+		{
+			Description: "generate struct without any defaults",
+			Src:         &parser.Struct{ItemName: "Empty", Fields: []parser.Field{{ItemName: "name", BaseItem: str, Meta: meta.Meta{Name: "name"}}}},
+			Expect:      "export type Empty = {\n    name: string;\n};",
+			Config:      *typescript.DefaultConfig().SetGenerateDefaults(true),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateTypeGuards(t *testing.T) {
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	num := &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger}
+	address := &parser.Struct{
+		ItemName: "Address",
+		Fields:   []parser.Field{{ItemName: "city", BaseItem: str, Meta: meta.Meta{Name: "city"}}},
+	}
+	user := &parser.Struct{
+		ItemName: "User",
+		Fields: []parser.Field{
+			{ItemName: "name", BaseItem: str, Meta: meta.Meta{Name: "name"}},
+			{ItemName: "age", BaseItem: num, Meta: meta.Meta{Name: "age", Optional: meta.OptionalTrue}},
+			{ItemName: "tags", BaseItem: &parser.List{BaseItem: str, Length: parser.EmptyLength}, Meta: meta.Meta{Name: "tags"}},
+			{ItemName: "address", BaseItem: &parser.Struct{ItemName: "Address", Fields: address.Fields, Nullable: true}, Meta: meta.Meta{Name: "address"}},
+			{ItemName: "extra", BaseItem: &parser.Scalar{ItemName: "any", ItemType: parser.TypeAny}, Meta: meta.Meta{Name: "extra"}},
+			{ItemName: "secret", BaseItem: str, Meta: meta.Meta{Name: "secret", Skip: true}},
+		},
+	}
+
+	declaration := "export type User = {\n    name: string;\n    age?: number | null;\n    tags: Array<string>;\n    address: Address;\n    extra: any;\n};"
+
+	tests := []Test{
+		{
+			Description: "generate struct without type guards enabled",
+			Src:         user,
+			Expect:      declaration,
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate struct with type guard",
+			Src:         user,
+			Expect: declaration + "\n\nexport function isUser(value: unknown): value is User {\n" +
+				"    if (typeof value !== \"object\" || value === null) {\n        return false;\n    }\n\n" +
+				"    const v = value as Record<string, unknown>;\n    return (\n" +
+				"        typeof v[\"name\"] === \"string\" &&\n" +
+				"        (v[\"age\"] === null || v[\"age\"] === undefined || typeof v[\"age\"] === \"number\") &&\n" +
+				"        Array.isArray(v[\"tags\"]) && v[\"tags\"].every((e0) => typeof e0 === \"string\") &&\n" +
+				"        isAddress(v[\"address\"]) &&\n" +
+				"        \"extra\" in v\n    );\n}",
+			Config: *typescript.DefaultConfig().SetGenerateTypeGuards(true),
+		},
+		{
+			Description: "generate type guard with inlined objects and field naming",
+			Src: &parser.Struct{
+				ItemName: "Profile",
+				Fields: []parser.Field{
+					{ItemName: "HomeAddress", BaseItem: address},
+					{ItemName: "Scores", BaseItem: &parser.Map{Key: str, Value: num}},
+				},
+			},
+			Expect: "export type Profile = {\n    home_address: {\n        city: string;\n    };\n    scores: Record<string, number>;\n};\n\n" +
+				"export function isProfile(value: unknown): value is Profile {\n" +
+				"    if (typeof value !== \"object\" || value === null) {\n        return false;\n    }\n\n" +
+				"    const v = value as Record<string, unknown>;\n    return (\n" +
+				"        typeof v[\"home_address\"] === \"object\" && v[\"home_address\"] !== null && typeof (v[\"home_address\"] as Record<string, unknown>)[\"city\"] === \"string\" &&\n" +
+				"        typeof v[\"scores\"] === \"object\" && v[\"scores\"] !== null && !Array.isArray(v[\"scores\"]) && Object.values(v[\"scores\"]).every((v0) => typeof v0 === \"number\")\n    );\n}",
+			Config: *typescript.DefaultConfig().SetGenerateTypeGuards(true).SetInlineObjects(true).SetFieldNaming(naming.SnakeCase),
+		},
+		{
+			Description: "generate type guard for tuple",
+			Src:         &parser.List{ItemName: "Point", BaseItem: num, Length: 2},
+			Expect:      "export type Point = [number, number];\n\nexport function isPoint(value: unknown): value is Point {\n    return Array.isArray(value) && value.length === 2 && value.every((e0) => typeof e0 === \"number\");\n}",
+			Config:      *typescript.DefaultConfig().SetGenerateTypeGuards(true).SetArraysAsTuples(true),
+		},
+		{
+			Description: "generate type guard for discriminated union",
+			Src: &parser.Union{
+				ItemName:      "Event",
+				Discriminator: "type",
+				Variants: []parser.UnionVariant{
+					{Tag: "created", Item: &parser.Struct{ItemName: "EventCreated", Fields: []parser.Field{{ItemName: "id", BaseItem: str, Meta: meta.Meta{Name: "id"}}}}},
+					{Tag: "deleted", Item: &parser.Struct{ItemName: "EventDeleted"}},
+				},
+			},
+			Expect: "export type Event = {\n    type: \"created\";\n    id: string;\n} | {\n    type: \"deleted\";\n};\n\n" +
+				"export function isEvent(value: unknown): value is Event {\n" +
+				"    return typeof value === \"object\" && value !== null && (((value as Record<string, unknown>)[\"type\"] === \"created\" && typeof (value as Record<string, unknown>)[\"id\"] === \"string\") || ((value as Record<string, unknown>)[\"type\"] === \"deleted\"));\n}",
+			Config: *typescript.DefaultConfig().SetGenerateTypeGuards(true),
+		},
+		{
+			Description: "generate type guard for struct without fields",
+			Src:         &parser.Struct{ItemName: "Empty"},
+			Expect:      "export type Empty = {\n\n};\n\nexport function isEmpty(value: unknown): value is Empty {\n    if (typeof value !== \"object\" || value === null) {\n        return false;\n    }\n\n    return true;\n}",
+			Config:      *typescript.DefaultConfig().SetGenerateTypeGuards(true),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateRPCFunctions(t *testing.T) {
+	var (
+		ctx     = &parser.Scalar{ItemName: "Context", PkgPath: "context", ItemType: parser.TypeAny}
+		err     = &parser.Scalar{ItemName: "error", ItemType: parser.TypeString}
+		request = &parser.Struct{ItemName: "GetUserRequest"}
+		user    = &parser.Struct{ItemName: "User"}
+	)
+
+	getUser := &parser.Function{
+		ItemName:   "GetUser",
+		Params:     []parser.Item{ctx, request},
+		ParamNames: []string{"ctx", "request"},
+		Returns:    []parser.Item{user, err},
+	}
+
+	tests := []Test{
+		{
+			Description: "generate function with named params",
+			Src:         getUser,
+			Expect:      "export type GetUser = (ctx: any, request: GetUserRequest) => [User, string];",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate function without context and error",
+			Src:         getUser,
+			Expect:      "export type GetUser = (request: GetUserRequest) => User;",
+			Config:      *typescript.DefaultConfig().SetOmitContextParams(true).SetOmitErrorReturns(true),
+		},
+		{
+			Description: "generate async function",
+			Src:         getUser,
+			Expect:      "export type GetUser = (request: GetUserRequest) => Promise<User>;",
+			Config:      *typescript.DefaultConfig().SetOmitContextParams(true).SetOmitErrorReturns(true).SetAsyncFunctions(true),
+		},
+		{
+			Description: "generate async function with multiple returns",
+			Src: &parser.Function{
+				ItemName: "Lookup",
+				Params:   []parser.Item{ctx, &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}},
+				Returns:  []parser.Item{user, &parser.Scalar{ItemName: "bool", ItemType: parser.TypeBoolean}, err},
+			},
+			Expect: "export type Lookup = (arg0: string) => Promise<[User, boolean]>;",
+			Config: *typescript.DefaultConfig().SetOmitContextParams(true).SetOmitErrorReturns(true).SetAsyncFunctions(true),
+		},
+		{
+			Description: "generate async function returning only an error",
+			Src:         &parser.Function{ItemName: "Ping", Params: []parser.Item{ctx}, Returns: []parser.Item{err}},
+			Expect:      "export type Ping = () => Promise<void>;",
+			Config:      *typescript.DefaultConfig().SetOmitContextParams(true).SetOmitErrorReturns(true).SetAsyncFunctions(true),
+		},
+		{
+			Description: "generate async function returning an (async) function",
+			Src: &parser.Function{
+				ItemName: "Subscribe",
+				Returns:  []parser.Item{&parser.Function{ItemName: "Unsubscribe"}},
+			},
+			Expect: "export type Subscribe = () => Promise<() => Promise<void>>;",
+			Config: *typescript.DefaultConfig().SetAsyncFunctions(true),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateBrandedTypes(t *testing.T) {
+	var (
+		userID  = &parser.Scalar{ItemName: "UserID", PkgPath: "models", ItemType: parser.TypeString, Named: true}
+		counter = &parser.Scalar{ItemName: "Counter", PkgPath: "models", ItemType: parser.TypeInteger, BitSize: 64, Named: true}
+		name    = &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	)
+
+	tests := []Test{
+		{
+			Description: "generate branded type",
+			Src:         userID,
+			Expect:      `export type UserID = string & { readonly __brand: "UserID" };`,
+			Config:      *typescript.DefaultConfig().SetBrandedTypes(true),
+		},
+		{
+			Description: "generate branded 64-bit integer as bigint",
+			Src:         counter,
+			Expect:      `export type Counter = bigint & { readonly __brand: "Counter" };`,
+			Config:      *typescript.DefaultConfig().SetBrandedTypes(true).SetInt64Mode(typescript.Int64AsBigInt),
+		},
+		{
+			Description: "generate named scalar without branding",
+			Src:         userID,
+			Expect:      "export type UserID = string;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate struct referencing branded types",
+			Src: &parser.Struct{
+				ItemName: "User",
+				Fields: []parser.Field{
+					{ItemName: "id", BaseItem: userID},
+					{ItemName: "name", BaseItem: name},
+					{ItemName: "friends", BaseItem: &parser.List{ItemName: "", BaseItem: userID}},
+					{ItemName: "visits", BaseItem: &parser.Map{ItemName: "", Key: userID, Value: counter}},
+				},
+			},
+			Expect: `export type User = {
+    id: UserID;
+    name: string;
+    friends: Array<UserID>;
+    visits: Record<UserID, Counter>;
+};`,
+			Config: *typescript.DefaultConfig().SetBrandedTypes(true),
+		},
+		{
+			Description: "generate bigint branded map key as string",
+			Src:         &parser.Map{ItemName: "Visits", Key: counter, Value: name},
+			Expect:      "export type Visits = Record<string, string>;",
+			Config:      *typescript.DefaultConfig().SetBrandedTypes(true).SetInt64Mode(typescript.Int64AsBigInt),
+		},
+		{
+			Description: "generate custom type instead of branded type",
+			Src:         &parser.Struct{ItemName: "Session", Fields: []parser.Field{{ItemName: "user", BaseItem: userID}}},
+			Expect: `export type Session = {
+    user: ID;
+};`,
+			Config: func() typescript.Config {
+				c := typescript.DefaultConfig().SetBrandedTypes(true)
+				c.AddCustomType("models.UserID", "ID")
+				return *c
+			}(),
+		},
+		{
+			Description: "generate defaults for branded types",
+			Src: &parser.Struct{
+				ItemName: "Account",
+				Fields: []parser.Field{
+					{ItemName: "owner", BaseItem: userID, Meta: meta.Meta{Name: "owner", Default: `"root"`}},
+					{ItemName: "logins", BaseItem: counter, Meta: meta.Meta{Name: "logins", Default: "0"}},
+					{ItemName: "name", BaseItem: name, Meta: meta.Meta{Name: "name", Default: `"admin"`}},
+				},
+			},
+			Expect: `export type Account = {
+    owner: UserID;
+    logins: Counter;
+    name: string;
+};
+
+export const defaultAccount: Account = {
+    owner: "root" as UserID,
+    logins: 0n as Counter,
+    name: "admin",
+};`,
+			Config: *typescript.DefaultConfig().SetBrandedTypes(true).SetGenerateDefaults(true).SetInt64Mode(typescript.Int64AsBigInt),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_GenerateMapKeys(t *testing.T) {
+	var (
+		role     = &parser.Scalar{ItemName: "Role", PkgPath: "models", ItemType: parser.TypeString, Named: true, Enum: []string{`"admin"`, `"user"`}}
+		priority = &parser.Scalar{ItemName: "Priority", PkgPath: "models", ItemType: parser.TypeInteger, BitSize: 64, Named: true, Enum: []string{"1", "2"}}
+		value    = &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+	)
+
+	tests := []Test{
+		{
+			Description: "generate string enum",
+			Src:         role,
+			Expect:      `export type Role = "admin" | "user";`,
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate integer enum",
+			Src:         priority,
+			Expect:      "export type Priority = 1 | 2;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate 64-bit integer enum as strings",
+			Src:         priority,
+			Expect:      `export type Priority = "1" | "2";`,
+			Config:      *typescript.DefaultConfig().SetInt64Mode(typescript.Int64AsString),
+		},
+		{
+			Description: "generate integer key as string",
+			Src:         &parser.Map{ItemName: "Counts", Key: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, BitSize: 64}, Value: value},
+			Expect:      "export type Counts = Record<string, string>;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate text marshaler key as string",
+			Src:         &parser.Map{ItemName: "Events", Key: &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeString}, Value: value},
+			Expect:      "export type Events = Record<string, string>;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate string enum key",
+			Src:         &parser.Map{ItemName: "Permissions", Key: role, Value: value},
+			Expect:      "export type Permissions = Partial<Record<Role, string>>;",
+			Config:      *typescript.DefaultConfig(),
+		},
+		{
+			Description: "generate readonly integer enum key",
+			Src:         &parser.Map{ItemName: "Queues", Key: priority, Value: value},
+			Expect:      "export type Queues = Readonly<Partial<Record<Priority, string>>>;",
+			Config:      *typescript.DefaultConfig().SetReadonly(true),
+		},
+		{
+			Description: "generate bigint enum key as string",
+			Src:         &parser.Map{ItemName: "Queues", Key: priority, Value: value},
+			Expect:      "export type Queues = Record<string, string>;",
+			Config:      *typescript.DefaultConfig().SetInt64Mode(typescript.Int64AsBigInt),
+		},
+		{
+			Description: "generate boolean key",
+			Src:         &parser.Map{ItemName: "Flags", Key: &parser.Scalar{ItemName: "bool", ItemType: parser.TypeBoolean}, Value: value},
+			WantErr:     true,
+		},
+		{
+			Description: "generate type guard for enum",
+			Src:         &parser.Struct{ItemName: "Member", Fields: []parser.Field{{ItemName: "role", BaseItem: role}}},
+			Expect: `export type Member = {
+    role: Role;
+};
+
+export function isMember(value: unknown): value is Member {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const v = value as Record<string, unknown>;
+    return (
+        (v["role"] === "admin" || v["role"] === "user")
+    );
+}`,
+			Config: *typescript.DefaultConfig().SetGenerateTypeGuards(true),
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func Test_Header(t *testing.T) {
+	cfg := typescript.DefaultConfig()
+	header := cfg.Header()
+
+	cfg.AddImport("./uuid", "UUID").AddImport("decimal.js", "Decimal").AddImport("./uuid", "UUID", "Brand")
+
+	expect := header + "\n" + `import type { Brand, UUID } from "./uuid";` + "\n" + `import type { Decimal } from "decimal.js";` + "\n"
+	if got := cfg.Header(); got != expect {
+		t.Errorf("expected header %q, got %q", expect, got)
+	}
+}
+
 func Test_GenerateItemType(t *testing.T) {
 	tests := []Test{
 		{
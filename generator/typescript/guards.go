@@ -0,0 +1,317 @@
+package typescript
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+// recordCast is used to access the properties of a value that has already been checked to be an object
+const recordCast = "(%s as Record<string, unknown>)"
+
+// guardName returns the name of the type guard for a type with the given (Go) name (e.g. `isUser`)
+func (g *Generator) guardName(name string) string {
+	return "is" + g.declaredName(name)
+}
+
+// generateTypeGuard generates a type guard function for an item (e.g. `export function isUser(value: unknown): value is User { ... }`)
+// Fields are checked for their presence and (scalar) types, lists and maps are checked element by element and referenced types are checked by calling their own type guards
+func (g *Generator) generateTypeGuard(item parser.Item) (string, error) {
+	var (
+		typeName = g.typeName(item)
+		body     string
+	)
+
+	if structItem, ok := item.(*parser.Struct); ok && !g.hasCustomType(item) {
+		checks, err := g.fieldGuards(structItem.Fields, "v", 0)
+		if err != nil {
+			return "", err
+		}
+
+		body = g.indent + "if (typeof value !== \"object\" || value === null) {\n" +
+			strings.Repeat(g.indent, 2) + "return false;\n" +
+			g.indent + "}\n\n"
+
+		if item.IsNullable() {
+			body = g.indent + "if (value === null || value === undefined) {\n" +
+				strings.Repeat(g.indent, 2) + "return true;\n" +
+				g.indent + "}\n\n" + body
+		}
+
+		if len(checks) == 0 {
+			body += g.indent + "return true;"
+		} else {
+			body += g.indent + "const v = value as Record<string, unknown>;\n" +
+				g.indent + "return (\n" +
+				strings.Repeat(g.indent, 2) + strings.Join(checks, " &&\n"+strings.Repeat(g.indent, 2)) + "\n" +
+				g.indent + ");"
+		}
+	} else {
+		// The declaration itself is checked by shape, referencing it would make the guard call itself
+		check := "true"
+		if !g.hasCustomType(item) {
+			var err error
+			if check, err = g.shapeGuard(item, "value", 0); err != nil {
+				return "", err
+			}
+		}
+
+		body = g.indent + "return " + nullableGuard(item, "value", meta.OptionalNone, check) + ";"
+	}
+
+	return fmt.Sprintf("export function %s(value: unknown): value is %s {\n%s\n}", g.guardName(item.Name()), typeName, body), nil
+}
+
+// guard returns a boolean expression that checks if `expr` matches the item, including its nullability and optionality
+func (g *Generator) guard(item parser.Item, expr string, metadata *meta.Meta, depth int) (string, error) {
+	var (
+		check string
+		err   error
+	)
+
+	// Overridden types cannot be checked since they are arbitrary typescript types
+	if metadata != nil && metadata.Type != "" {
+		check = "true"
+	} else if check, err = g.guardCheck(item, expr, depth); err != nil {
+		return "", err
+	}
+
+	var optional meta.Optional
+	if metadata != nil {
+		optional = metadata.Optional
+	}
+
+	// Referenced declarations already include their own nullability (and so do their type guards), only optional fields can be missing
+	if g.isReference(item) && !optional.IsTrue() {
+		return check, nil
+	}
+
+	return nullableGuard(item, expr, optional, check), nil
+}
+
+// nullableGuard wraps a check to accept missing and null values for nullable and optional items
+func nullableGuard(item parser.Item, expr string, optional meta.Optional, check string) string {
+	if check == "true" || !((item.IsNullable() && optional.IsNone()) || optional.IsTrue()) {
+		return check
+	}
+
+	return fmt.Sprintf("(%s === null || %s === undefined || %s)", expr, expr, check)
+}
+
+// guardCheck returns a boolean expression that checks if `expr` matches the item without any nullability information
+func (g *Generator) guardCheck(item parser.Item, expr string, depth int) (string, error) {
+	// Custom types are arbitrary typescript types, so they cannot be checked
+	if g.hasCustomType(item) {
+		return "true", nil
+	}
+
+	// Referenced types are checked with their own type guards
+	if g.isReference(item) {
+		if !g.referenceExists(item.Name()) {
+			return "", fmt.Errorf("referenced type `%s` does not exist, you need to either enable inline objects or pass in the referenced type", item.Name())
+		}
+
+		return g.guardReference(item) + "(" + expr + ")", nil
+	}
+
+	return g.shapeGuard(item, expr, depth)
+}
+
+// shapeGuard returns a boolean expression that checks if `expr` has the shape of the (inlined) item
+func (g *Generator) shapeGuard(item parser.Item, expr string, depth int) (string, error) {
+	switch item := item.(type) {
+	case *parser.Scalar:
+		return g.scalarGuard(item, expr)
+
+	case *parser.List:
+		return g.listGuard(item, expr, depth)
+
+	case *parser.Map:
+		return g.mapGuard(item, expr, depth)
+
+	case *parser.Struct:
+		checks, err := g.fieldGuards(item.Fields, fmt.Sprintf(recordCast, expr), depth)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.Join(append([]string{isObject(expr)}, checks...), " && "), nil
+
+	case *parser.Union:
+		return g.unionGuard(item, expr, depth)
+
+	case *parser.Function:
+		return fmt.Sprintf("typeof %s === \"function\"", expr), nil
+
+	default:
+		return "", fmt.Errorf("unknown type: %T", item)
+	}
+}
+
+// scalarGuard checks the type of a scalar with `typeof` (or a comparison for `null` and `void`), enums are compared against each of their values
+func (g *Generator) scalarGuard(item *parser.Scalar, expr string) (string, error) {
+	if g.isEnum(item) {
+		values, err := g.enumLiterals(item)
+		if err != nil {
+			return "", err
+		}
+
+		checks := make([]string, 0, len(values))
+		for _, value := range values {
+			checks = append(checks, expr+" === "+value)
+		}
+
+		return "(" + strings.Join(checks, " || ") + ")", nil
+	}
+
+	// Brands only exist at compile time, so branded types are checked by their underlying type
+	typeValue, err := g.scalarType(item)
+	if err != nil {
+		return "", err
+	}
+
+	switch typeValue {
+	case "any", "unknown":
+		return "true", nil
+	case "void":
+		return expr + " === undefined", nil
+	case "null":
+		return expr + " === null", nil
+	default:
+		return fmt.Sprintf("typeof %s === %q", expr, typeValue), nil
+	}
+}
+
+// listGuard checks that a value is an array (with the exact length for tuples) and checks every element
+func (g *Generator) listGuard(item *parser.List, expr string, depth int) (string, error) {
+	if item.BaseItem == nil {
+		return "", fmt.Errorf("no base item found for list type: `%s`", item.Name())
+	}
+
+	check := fmt.Sprintf("Array.isArray(%s)", expr)
+	if g.isTuple(item) {
+		check += fmt.Sprintf(" && %s.length === %d", expr, item.Length)
+	}
+
+	elem := "e" + strconv.Itoa(depth)
+	elemCheck, err := g.guard(item.BaseItem, elem, nil, depth+1)
+	if err != nil {
+		return "", err
+	}
+
+	if elemCheck != "true" {
+		check += fmt.Sprintf(" && %s.every((%s) => %s)", expr, elem, elemCheck)
+	}
+
+	return check, nil
+}
+
+// mapGuard checks that a value is a (non-array) object and checks every value, keys are always strings in JSON so they are not checked
+func (g *Generator) mapGuard(item *parser.Map, expr string, depth int) (string, error) {
+	if item.Key == nil || item.Value == nil {
+		return "", fmt.Errorf("key or value is nil for map type: `%s`", item.Name())
+	}
+
+	check := isObject(expr) + fmt.Sprintf(" && !Array.isArray(%s)", expr)
+
+	value := "v" + strconv.Itoa(depth)
+	valueCheck, err := g.guard(item.Value, value, nil, depth+1)
+	if err != nil {
+		return "", err
+	}
+
+	if valueCheck != "true" {
+		check += fmt.Sprintf(" && Object.values(%s).every((%s) => %s)", expr, value, valueCheck)
+	}
+
+	return check, nil
+}
+
+// unionGuard checks that a value is an object matching any of the union's variants based on the discriminator
+func (g *Generator) unionGuard(item *parser.Union, expr string, depth int) (string, error) {
+	obj := fmt.Sprintf(recordCast, expr)
+
+	variants := make([]string, 0, len(item.Variants))
+	for _, variant := range item.Variants {
+		variantStruct, ok := variant.Item.(*parser.Struct)
+		if !ok {
+			return "", fmt.Errorf("variant `%s` of union `%s` is not an object type", variant.Tag, item.Name())
+		}
+
+		// The discriminator is checked against the variant's tag instead of the variant's own field (if any)
+		fields := make([]parser.Field, 0, len(variantStruct.Fields))
+		for _, field := range variantStruct.Fields {
+			if field.Meta.Name == item.Discriminator || (field.Meta.Name == "" && field.ItemName == item.Discriminator) {
+				continue
+			}
+
+			fields = append(fields, field)
+		}
+
+		checks, err := g.fieldGuards(fields, obj, depth)
+		if err != nil {
+			return "", err
+		}
+
+		checks = append([]string{fmt.Sprintf("%s[%q] === %q", obj, item.Discriminator, variant.Tag)}, checks...)
+		variants = append(variants, "("+strings.Join(checks, " && ")+")")
+	}
+
+	return isObject(expr) + " && (" + strings.Join(variants, " || ") + ")", nil
+}
+
+// fieldGuards returns the checks for each (non-skipped) field of a struct, `obj` is an expression of the type `Record<string, unknown>`
+func (g *Generator) fieldGuards(fields []parser.Field, obj string, depth int) ([]string, error) {
+	checks := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Meta.Skip {
+			continue
+		}
+
+		name := g.fieldName(field)
+		if name == "" {
+			return nil, fmt.Errorf("unable to find name for field `%s`", field.BaseItem.Name())
+		}
+
+		metadata := field.Meta
+		check, err := g.guard(field.BaseItem, fmt.Sprintf("%s[%q]", obj, name), &metadata, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		// Required fields without any checkable type must still be present
+		if check == "true" {
+			if field.Meta.Optional.IsTrue() || field.BaseItem.IsNullable() {
+				continue
+			}
+
+			check = fmt.Sprintf("%q in %s", name, obj)
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// guardReference returns the name of the type guard to call for a referenced item and records the reference so that it can be imported when the output is split across files
+func (g *Generator) guardReference(item parser.Item) string {
+	if g.guardReferences != nil {
+		g.guardReferences[parser.QualifiedName(item)] = item
+	}
+
+	return g.guardName(item.Name())
+}
+
+// hasCustomType checks if an item has been mapped to a custom type
+func (g *Generator) hasCustomType(item parser.Item) bool {
+	_, ok := g.customType(item)
+	return ok
+}
+
+func isObject(expr string) string {
+	return fmt.Sprintf("typeof %s === \"object\" && %s !== null", expr, expr)
+}
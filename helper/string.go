@@ -1,6 +1,9 @@
 package helper
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 func WithDefaultString(value string, defaultValue string) string {
 	if strings.TrimSpace(value) == "" {
@@ -14,3 +17,82 @@ func StringToBool(value string) bool {
 	value = strings.ToLower(strings.TrimSpace(value))
 	return value == "true" || value == "1"
 }
+
+// SplitWords splits an identifier into its words, handling camelCase, PascalCase (including acronyms like `HTTPServer`), snake_case and kebab-case
+func SplitWords(value string) []string {
+	var (
+		words   []string
+		current []rune
+		runes   = []rune(value)
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			flush()
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+// ToKebabCase converts an identifier to kebab-case (e.g. `UserProfile` -> `user-profile`)
+func ToKebabCase(value string) string {
+	return strings.ToLower(strings.Join(SplitWords(value), "-"))
+}
+
+// ToSnakeCase converts an identifier to snake_case (e.g. `UserProfile` -> `user_profile`)
+func ToSnakeCase(value string) string {
+	return strings.ToLower(strings.Join(SplitWords(value), "_"))
+}
+
+// ToPascalCase converts an identifier to PascalCase (e.g. `user_profile` -> `UserProfile`), acronyms are treated as regular words (e.g. `UserID` -> `UserId`)
+func ToPascalCase(value string) string {
+	words := SplitWords(value)
+	for idx, word := range words {
+		words[idx] = capitalize(word)
+	}
+
+	return strings.Join(words, "")
+}
+
+// ToCamelCase converts an identifier to camelCase (e.g. `UserProfile` -> `userProfile`), acronyms are treated as regular words (e.g. `UserID` -> `userId`)
+func ToCamelCase(value string) string {
+	words := SplitWords(value)
+	for idx, word := range words {
+		if idx == 0 {
+			words[idx] = strings.ToLower(word)
+		} else {
+			words[idx] = capitalize(word)
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes)
+}
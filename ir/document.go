@@ -0,0 +1,63 @@
+package ir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+// Version is the version of the IR document format, it is bumped on breaking changes to the format
+const Version = 1
+
+// Document is a versioned collection of parsed items, it is the stable format used to exchange type models with other tools
+type Document struct {
+	// Version is the format version of the document (see Version)
+	Version int `json:"version"`
+
+	// Items are the top-level items (usually the sources added to mirror) in the order they were added
+	Items []*Node `json:"items"`
+}
+
+// NewDocument encodes the items into a document with the current version
+func NewDocument(items ...parser.Item) (*Document, error) {
+	doc := &Document{Version: Version, Items: make([]*Node, 0, len(items))}
+	for _, item := range items {
+		node, err := Encode(item)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.Items = append(doc.Items, node)
+	}
+
+	return doc, nil
+}
+
+// Write writes the document as indented JSON
+func (d *Document) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(d)
+}
+
+// Read reads a document and ensures it is a version that can be understood
+func Read(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode IR document: %w", err)
+	}
+
+	if doc.Version == 0 {
+		return nil, errors.New("missing IR document version")
+	}
+
+	if doc.Version > Version {
+		return nil, fmt.Errorf("unsupported IR document version %d, the latest supported version is %d", doc.Version, Version)
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,408 @@
+package ir
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+// Kind is the kind of a node, it maps to one of the built-in parser items
+type Kind string
+
+const (
+	KindScalar   Kind = "scalar"
+	KindStruct   Kind = "struct"
+	KindList     Kind = "list"
+	KindMap      Kind = "map"
+	KindFunction Kind = "function"
+	KindUnion    Kind = "union"
+)
+
+// Node is the serializable (JSON) representation of a parsed item, it is used to exchange the parsed item tree with other tools and processes (e.g. generator plugins)
+type Node struct {
+	Kind Kind `json:"kind"`
+
+	// Name is the name of the Go type (empty for unnamed types)
+	Name string `json:"name,omitempty"`
+
+	// PkgPath is the import path of the package the Go type was declared in (empty for built-in and unnamed types)
+	PkgPath string `json:"pkg_path,omitempty"`
+
+	// Type is the mirror type of the item (e.g. "int", "string", "struct", "list")
+	Type parser.Type `json:"type"`
+
+	Nullable bool `json:"nullable,omitempty"`
+
+	// BitSize, Unsigned, Named and Enum are only set for scalars
+	BitSize  int      `json:"bit_size,omitempty"`
+	Unsigned bool     `json:"unsigned,omitempty"`
+	Named    bool     `json:"named,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+
+	// Fields is only set for structs
+	Fields []Field `json:"fields,omitempty"`
+
+	// Elem and Length are only set for lists, the length is -1 for slices
+	Elem   *Node `json:"elem,omitempty"`
+	Length int   `json:"length,omitempty"`
+
+	// Key and Value are only set for maps
+	Key   *Node `json:"key,omitempty"`
+	Value *Node `json:"value,omitempty"`
+
+	// Params, ParamNames and Returns are only set for functions
+	Params     []*Node  `json:"params,omitempty"`
+	ParamNames []string `json:"param_names,omitempty"`
+	Returns    []*Node  `json:"returns,omitempty"`
+
+	// Discriminator and Variants are only set for unions
+	Discriminator string    `json:"discriminator,omitempty"`
+	Variants      []Variant `json:"variants,omitempty"`
+}
+
+// Field is the serializable representation of a struct field and its meta information
+type Field struct {
+	// Name is the name of the field in the target language (from struct tags, hooks etc)
+	Name string `json:"name"`
+
+	// OriginalName is the name of the field in the Go struct
+	OriginalName string `json:"original_name"`
+
+	// ExplicitName is true if the name was set explicitly (via struct tags or hooks), naming strategies should only be applied to names that are not explicit
+	ExplicitName bool `json:"explicit_name,omitempty"`
+
+	// TypeOverride is the type the user has overridden the field's type with (if any)
+	TypeOverride string `json:"type_override,omitempty"`
+
+	// Optional is one of "none", "true" or "false"
+	Optional string `json:"optional"`
+
+	Skip bool `json:"skip,omitempty"`
+
+	Readonly bool `json:"readonly,omitempty"`
+
+	// Default is the default value of the field encoded as JSON (if any)
+	Default string `json:"default,omitempty"`
+
+	Constraints *Constraints `json:"constraints,omitempty"`
+
+	// EmbeddedFrom is the embedded struct the field was promoted from (if any)
+	EmbeddedFrom *Reference `json:"embedded_from,omitempty"`
+
+	Item *Node `json:"item"`
+}
+
+// Reference is a reference to a named type by its name and package, it is used where including the full node is unnecessary
+type Reference struct {
+	Name    string `json:"name"`
+	PkgPath string `json:"pkg_path,omitempty"`
+}
+
+// Constraints is the serializable representation of a field's validation rules
+type Constraints struct {
+	Required         bool     `json:"required,omitempty"`
+	MinLength        *int     `json:"min_length,omitempty"`
+	MaxLength        *int     `json:"max_length,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusive_minimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusive_maximum,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	Enum             []string `json:"enum,omitempty"`
+	Format           string   `json:"format,omitempty"`
+}
+
+// Variant is the serializable representation of a union variant
+type Variant struct {
+	Tag  string `json:"tag"`
+	Item *Node  `json:"item"`
+}
+
+var ErrNilItem = errors.New("item cannot be nil")
+
+// Encode converts a parsed item (and every item it references) to a node
+func Encode(item parser.Item) (*Node, error) {
+	if item == nil {
+		return nil, ErrNilItem
+	}
+
+	node := &Node{
+		Name:     item.Name(),
+		PkgPath:  parser.PackagePath(item),
+		Type:     item.Type(),
+		Nullable: item.IsNullable(),
+	}
+
+	var err error
+
+	switch item := item.(type) {
+	case *parser.Scalar:
+		node.Kind = KindScalar
+		node.BitSize = item.BitSize
+		node.Unsigned = item.Unsigned
+		node.Named = item.Named
+		node.Enum = slices.Clone(item.Enum)
+
+	case *parser.Struct:
+		node.Kind = KindStruct
+		for _, field := range item.Fields {
+			encodedField := Field{
+				Name:         field.Meta.Name,
+				OriginalName: field.Meta.OriginalName,
+				ExplicitName: field.Meta.ExplicitName,
+				TypeOverride: field.Meta.Type,
+				Optional:     field.Meta.Optional.String(),
+				Skip:         field.Meta.Skip,
+				Readonly:     field.Meta.Readonly,
+				Default:      field.Meta.Default,
+			}
+
+			// Fields built by hand (e.g. by custom parsers) may not have any meta
+			if encodedField.Name == "" {
+				encodedField.Name = field.ItemName
+			}
+
+			if !field.Meta.Constraints.IsEmpty() {
+				encodedField.Constraints = encodeConstraints(field.Meta.Constraints)
+			}
+
+			if field.EmbeddedFrom != nil {
+				encodedField.EmbeddedFrom = &Reference{Name: field.EmbeddedFrom.ItemName, PkgPath: field.EmbeddedFrom.PkgPath}
+			}
+
+			if encodedField.Item, err = Encode(field.BaseItem); err != nil {
+				return nil, fmt.Errorf("failed to encode field `%s` of `%s`: %w", field.ItemName, item.Name(), err)
+			}
+
+			node.Fields = append(node.Fields, encodedField)
+		}
+
+	case *parser.List:
+		node.Kind = KindList
+		node.Length = item.Length
+		if node.Elem, err = Encode(item.BaseItem); err != nil {
+			return nil, fmt.Errorf("failed to encode element of `%s`: %w", item.Name(), err)
+		}
+
+	case *parser.Map:
+		node.Kind = KindMap
+		if node.Key, err = Encode(item.Key); err != nil {
+			return nil, fmt.Errorf("failed to encode key of `%s`: %w", item.Name(), err)
+		}
+
+		if node.Value, err = Encode(item.Value); err != nil {
+			return nil, fmt.Errorf("failed to encode value of `%s`: %w", item.Name(), err)
+		}
+
+	case *parser.Function:
+		node.Kind = KindFunction
+		node.ParamNames = slices.Clone(item.ParamNames)
+		if node.Params, err = encodeAll(item.Params); err != nil {
+			return nil, fmt.Errorf("failed to encode params of `%s`: %w", item.Name(), err)
+		}
+
+		if node.Returns, err = encodeAll(item.Returns); err != nil {
+			return nil, fmt.Errorf("failed to encode returns of `%s`: %w", item.Name(), err)
+		}
+
+	case *parser.Union:
+		node.Kind = KindUnion
+		node.Discriminator = item.Discriminator
+		for _, variant := range item.Variants {
+			encodedVariant := Variant{Tag: variant.Tag}
+			if encodedVariant.Item, err = Encode(variant.Item); err != nil {
+				return nil, fmt.Errorf("failed to encode variant `%s` of `%s`: %w", variant.Tag, item.Name(), err)
+			}
+
+			node.Variants = append(node.Variants, encodedVariant)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported item type: %T", item)
+	}
+
+	return node, nil
+}
+
+// Decode converts a node (and every node it references) back to a parsed item
+func Decode(node *Node) (parser.Item, error) {
+	if node == nil {
+		return nil, errors.New("node cannot be nil")
+	}
+
+	var err error
+
+	switch node.Kind {
+	case KindScalar:
+		return &parser.Scalar{
+			ItemName: node.Name,
+			PkgPath:  node.PkgPath,
+			ItemType: node.Type,
+			BitSize:  node.BitSize,
+			Unsigned: node.Unsigned,
+			Named:    node.Named,
+			Enum:     slices.Clone(node.Enum),
+			Nullable: node.Nullable,
+		}, nil
+
+	case KindStruct:
+		item := &parser.Struct{ItemName: node.Name, PkgPath: node.PkgPath, Nullable: node.Nullable}
+		for _, field := range node.Fields {
+			decodedField := parser.Field{
+				ItemName: field.Name,
+				Meta: meta.Meta{
+					OriginalName: field.OriginalName,
+					Name:         field.Name,
+					ExplicitName: field.ExplicitName,
+					Type:         field.TypeOverride,
+					Optional:     decodeOptional(field.Optional),
+					Skip:         field.Skip,
+					Readonly:     field.Readonly,
+					Default:      field.Default,
+				},
+			}
+
+			if field.Constraints != nil {
+				decodedField.Meta.Constraints = decodeConstraints(field.Constraints)
+			}
+
+			// Only the name of the embedded struct is kept in the IR, which is all generators need to reference it
+			if field.EmbeddedFrom != nil {
+				decodedField.EmbeddedFrom = &parser.Struct{ItemName: field.EmbeddedFrom.Name, PkgPath: field.EmbeddedFrom.PkgPath}
+			}
+
+			if decodedField.BaseItem, err = Decode(field.Item); err != nil {
+				return nil, fmt.Errorf("failed to decode field `%s` of `%s`: %w", field.Name, node.Name, err)
+			}
+
+			item.Fields = append(item.Fields, decodedField)
+		}
+
+		return item, nil
+
+	case KindList:
+		item := &parser.List{ItemName: node.Name, PkgPath: node.PkgPath, Nullable: node.Nullable, Length: node.Length}
+		if item.BaseItem, err = Decode(node.Elem); err != nil {
+			return nil, fmt.Errorf("failed to decode element of `%s`: %w", node.Name, err)
+		}
+
+		return item, nil
+
+	case KindMap:
+		item := &parser.Map{ItemName: node.Name, PkgPath: node.PkgPath, Nullable: node.Nullable}
+		if item.Key, err = Decode(node.Key); err != nil {
+			return nil, fmt.Errorf("failed to decode key of `%s`: %w", node.Name, err)
+		}
+
+		if item.Value, err = Decode(node.Value); err != nil {
+			return nil, fmt.Errorf("failed to decode value of `%s`: %w", node.Name, err)
+		}
+
+		return item, nil
+
+	case KindFunction:
+		item := &parser.Function{ItemName: node.Name, PkgPath: node.PkgPath, ParamNames: slices.Clone(node.ParamNames), Nullable: node.Nullable}
+		if item.Params, err = decodeAll(node.Params); err != nil {
+			return nil, fmt.Errorf("failed to decode params of `%s`: %w", node.Name, err)
+		}
+
+		if item.Returns, err = decodeAll(node.Returns); err != nil {
+			return nil, fmt.Errorf("failed to decode returns of `%s`: %w", node.Name, err)
+		}
+
+		return item, nil
+
+	case KindUnion:
+		item := &parser.Union{
+			ItemName:      node.Name,
+			PkgPath:       node.PkgPath,
+			Discriminator: node.Discriminator,
+			Nullable:      node.Nullable,
+		}
+
+		for _, variant := range node.Variants {
+			decodedVariant := parser.UnionVariant{Tag: variant.Tag}
+			if decodedVariant.Item, err = Decode(variant.Item); err != nil {
+				return nil, fmt.Errorf("failed to decode variant `%s` of `%s`: %w", variant.Tag, node.Name, err)
+			}
+
+			item.Variants = append(item.Variants, decodedVariant)
+		}
+
+		return item, nil
+	}
+
+	return nil, fmt.Errorf("unknown node kind: `%s`", node.Kind)
+}
+
+func encodeAll(items []parser.Item) ([]*Node, error) {
+	var nodes []*Node
+	for _, item := range items {
+		node, err := Encode(item)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+func decodeAll(nodes []*Node) ([]parser.Item, error) {
+	var items []parser.Item
+	for _, node := range nodes {
+		item, err := Decode(node)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func encodeConstraints(c meta.Constraints) *Constraints {
+	return &Constraints{
+		Required:         c.Required,
+		MinLength:        c.MinLength,
+		MaxLength:        c.MaxLength,
+		Minimum:          c.Minimum,
+		Maximum:          c.Maximum,
+		ExclusiveMinimum: c.ExclusiveMinimum,
+		ExclusiveMaximum: c.ExclusiveMaximum,
+		Pattern:          c.Pattern,
+		Enum:             c.Enum,
+		Format:           c.Format,
+	}
+}
+
+func decodeConstraints(c *Constraints) meta.Constraints {
+	return meta.Constraints{
+		Required:         c.Required,
+		MinLength:        c.MinLength,
+		MaxLength:        c.MaxLength,
+		Minimum:          c.Minimum,
+		Maximum:          c.Maximum,
+		ExclusiveMinimum: c.ExclusiveMinimum,
+		ExclusiveMaximum: c.ExclusiveMaximum,
+		Pattern:          c.Pattern,
+		Enum:             c.Enum,
+		Format:           c.Format,
+	}
+}
+
+func decodeOptional(value string) meta.Optional {
+	switch value {
+	case meta.OptionalTrue.String():
+		return meta.OptionalTrue
+	case meta.OptionalFalse.String():
+		return meta.OptionalFalse
+	default:
+		return meta.OptionalNone
+	}
+}
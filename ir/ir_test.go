@@ -0,0 +1,147 @@
+package ir_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+type (
+	Shape interface{ isShape() }
+
+	Circle struct {
+		Radius float64 `json:"radius" validate:"required,gt=0"`
+	}
+
+	Square struct {
+		Side float64 `json:"side"`
+	}
+
+	Status string
+
+	Drawing struct {
+		ID       uint64            `json:"id,string"`
+		Title    *string           `json:"title,omitempty"`
+		Status   Status            `json:"status"`
+		Shapes   []Shape           `json:"shapes"`
+		Layers   [2]string         `json:"layers"`
+		Labels   map[string]string `json:"labels"`
+		Children []Drawing         `json:"children"`
+		OnRender func(int) error   `json:"-"`
+	}
+
+	Base struct {
+		ID        string `json:"id"`
+		CreatedAt int64  `json:"created_at"`
+	}
+
+	Account struct {
+		Base
+		Email string `json:"email"`
+	}
+)
+
+func (Circle) isShape() {}
+func (Square) isShape() {}
+
+func Test_EncodeDecode(t *testing.T) {
+	p := parser.New()
+	if err := p.AddUnion(
+		reflect.TypeOf((*Shape)(nil)).Elem(),
+		"kind",
+		parser.VariantOf("circle", Circle{}),
+		parser.VariantOf("square", Square{}),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.AddEnum(Status("draft"), Status("published")); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := p.Parse(reflect.TypeOf(Drawing{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := ir.Encode(item)
+	if err != nil {
+		t.Fatalf("failed to encode item: %v", err)
+	}
+
+	// The node must survive a round-trip through JSON
+	data, err := json.Marshal(node)
+	if err != nil {
+		t.Fatalf("failed to marshal node: %v", err)
+	}
+
+	var decodedNode ir.Node
+	if err = json.Unmarshal(data, &decodedNode); err != nil {
+		t.Fatalf("failed to unmarshal node: %v", err)
+	}
+
+	if !reflect.DeepEqual(node, &decodedNode) {
+		t.Fatalf("expected node to survive JSON round-trip, got:\n%s", data)
+	}
+
+	decoded, err := ir.Decode(&decodedNode)
+	if err != nil {
+		t.Fatalf("failed to decode node: %v", err)
+	}
+
+	// Decoding and re-encoding must produce the same node
+	reencoded, err := ir.Encode(decoded)
+	if err != nil {
+		t.Fatalf("failed to re-encode item: %v", err)
+	}
+
+	if !reflect.DeepEqual(node, reencoded) {
+		t.Errorf("expected decoded item to match the original item")
+	}
+
+	drawing, ok := decoded.(*parser.Struct)
+	if !ok {
+		t.Fatalf("expected struct, got %T", decoded)
+	}
+
+	if drawing.Name() != "Drawing" || drawing.PkgPath != "go.trulyao.dev/mirror/v2/ir_test" {
+		t.Errorf("unexpected name or package: %s (%s)", drawing.Name(), drawing.PkgPath)
+	}
+
+	status, _ := drawing.GetField("status")
+	if scalar, ok := status.BaseItem.(*parser.Scalar); !ok || !scalar.Named || len(scalar.Enum) != 2 {
+		t.Errorf("expected named scalar with enum values, got %#v", status.BaseItem)
+	}
+
+	shapes, _ := drawing.GetField("shapes")
+	union, ok := shapes.BaseItem.(*parser.List).BaseItem.(*parser.Union)
+	if !ok || union.Discriminator != "kind" || len(union.Variants) != 2 {
+		t.Fatalf("expected union with two variants, got %#v", shapes.BaseItem.(*parser.List).BaseItem)
+	}
+
+	radius, _ := union.Variants[0].Item.(*parser.Struct).GetField("radius")
+	if !radius.Meta.Constraints.Required || radius.Meta.Constraints.Minimum == nil || !radius.Meta.Constraints.ExclusiveMinimum {
+		t.Errorf("expected constraints to be preserved, got %+v", radius.Meta.Constraints)
+	}
+}
+
+func Test_DecodeInvalidNode(t *testing.T) {
+	tests := []struct {
+		Description string
+		Node        *ir.Node
+	}{
+		{Description: "nil node", Node: nil},
+		{Description: "unknown kind", Node: &ir.Node{Kind: "tuple"}},
+		{Description: "list without element", Node: &ir.Node{Kind: ir.KindList, Length: -1}},
+		{Description: "map without key", Node: &ir.Node{Kind: ir.KindMap, Value: &ir.Node{Kind: ir.KindScalar, Type: parser.TypeString}}},
+	}
+
+	for _, test := range tests {
+		if _, err := ir.Decode(test.Node); err == nil {
+			t.Errorf("[%s] expected error, got nil", test.Description)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package ir
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+var ErrUnsupported = errors.New("not supported by the IR parser, the types have already been parsed")
+
+// Parser reads the items of an IR document back in, it implements `types.ParserInterface` so that generation can be decoupled from the Go program that owns the types
+// Since there is no Go source to parse, adding sources or unions is not supported and field hooks are called without the parent type or original struct field
+type Parser struct {
+	mu sync.RWMutex
+
+	// nodes are the remaining top-level nodes
+	nodes []*Node
+
+	customTypes map[string]parser.Item
+
+	// order and sortFields are applied to the decoded items, the nodes themselves are kept as they are
+	order      parser.Order
+	sortFields bool
+
+	onParseItemFn  parser.OnParseItemFunc
+	onParseFieldFn parser.OnParseFieldFunc
+}
+
+// NewParser returns a parser for the items in the document
+func NewParser(doc *Document) *Parser {
+	p := &Parser{customTypes: make(map[string]parser.Item)}
+	if doc != nil {
+		p.nodes = append(p.nodes, doc.Items...)
+	}
+
+	return p
+}
+
+func (p *Parser) AddSource(reflect.Type) error { return ErrUnsupported }
+
+func (p *Parser) AddSources(...reflect.Type) error { return ErrUnsupported }
+
+// Parse is not supported, use the items in the document instead
+func (p *Parser) Parse(reflect.Type) (parser.Item, error) { return nil, ErrUnsupported }
+
+// AddCustomType replaces every item with the name (at any depth) with the provided item
+func (p *Parser) AddCustomType(name string, item parser.Item) error {
+	if name == "" {
+		return errors.New("custom type name cannot be empty")
+	}
+
+	if item == nil {
+		return errors.New("custom type item cannot be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.customTypes[name] = item
+	return nil
+}
+
+func (p *Parser) AddCustomTypes(customTypes []parser.CustomType) error {
+	for _, customType := range customTypes {
+		if err := p.AddCustomType(customType.Name, customType.Item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetConfig sets the order items are iterated in and whether their fields are sorted, the rest of the configuration only affects how Go types are parsed
+func (p *Parser) SetConfig(config parser.Config) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.order = config.Order
+	p.sortFields = config.SortFields
+
+	return nil
+}
+
+func (p *Parser) OnParseItem(fn parser.OnParseItemFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onParseItemFn = fn
+}
+
+func (p *Parser) OnParseField(fn parser.OnParseFieldFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onParseFieldFn = fn
+}
+
+func (p *Parser) Count() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return len(p.nodes)
+}
+
+func (p *Parser) Done() bool {
+	return p.Count() == 0
+}
+
+func (p *Parser) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nodes = nil
+}
+
+func (p *Parser) Next() (parser.Item, error) {
+	p.mu.Lock()
+	if len(p.nodes) == 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("no sources to parse")
+	}
+
+	node := p.nodes[0]
+	p.nodes = p.nodes[1:]
+	p.mu.Unlock()
+
+	return p.decode(node)
+}
+
+func (p *Parser) ParseN(n int) (parser.Item, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must be a positive integer")
+	}
+
+	p.mu.RLock()
+	if len(p.nodes) <= n {
+		p.mu.RUnlock()
+		return nil, fmt.Errorf("not enough sources to parse")
+	}
+
+	node := p.nodes[n]
+	p.mu.RUnlock()
+
+	return p.decode(node)
+}
+
+func (p *Parser) Iterate(f func(parser.Item) error) error {
+	nodes := p.snapshot()
+
+	items := make([]parser.Item, 0, len(nodes))
+	for _, node := range nodes {
+		item, err := p.decode(node)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, item)
+	}
+
+	p.mu.RLock()
+	order := p.order
+	p.mu.RUnlock()
+
+	for _, item := range parser.SortItems(items, order) {
+		if err := f(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) LookupByName(name string) (parser.Item, bool) {
+	for _, node := range p.snapshot() {
+		if node.Name == name {
+			item, err := p.decode(node)
+			if err != nil {
+				return nil, false
+			}
+
+			return item, true
+		}
+	}
+
+	return nil, false
+}
+
+// snapshot returns a copy of the remaining nodes so that they can be iterated without holding the lock
+func (p *Parser) snapshot() []*Node {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]*Node(nil), p.nodes...)
+}
+
+// decode decodes a top-level node, replaces custom types and runs the hooks, a fresh item is returned every time so hooks never see their own changes
+func (p *Parser) decode(node *Node) (parser.Item, error) {
+	item, err := Decode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	customTypes, onParseItemFn, onParseFieldFn, sortFields := p.customTypes, p.onParseItemFn, p.onParseFieldFn, p.sortFields
+	p.mu.RUnlock()
+
+	if customType, ok := customTypes[item.Name()]; ok {
+		item = customType
+	} else if item, err = p.prepare(item, customTypes, onParseFieldFn); err != nil {
+		return nil, err
+	} else if sortFields {
+		// Fields are sorted after the hooks have run, like the Go parser does, since hooks can rename them
+		parser.SortFields(item)
+	}
+
+	if onParseItemFn != nil {
+		if err = onParseItemFn(node.Name, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+// prepare replaces the nested custom types and runs the field hook on every struct field
+func (p *Parser) prepare(item parser.Item, customTypes map[string]parser.Item, onParseFieldFn parser.OnParseFieldFunc) (parser.Item, error) {
+	replace := func(item parser.Item) (parser.Item, error) {
+		if customType, ok := customTypes[item.Name()]; ok && item.Name() != "" {
+			return customType, nil
+		}
+
+		return p.prepare(item, customTypes, onParseFieldFn)
+	}
+
+	var err error
+
+	switch item := item.(type) {
+	case *parser.Struct:
+		for idx := range item.Fields {
+			field := &item.Fields[idx]
+			if field.BaseItem, err = replace(field.BaseItem); err != nil {
+				return nil, err
+			}
+
+			if onParseFieldFn != nil {
+				if err = onParseFieldFn(nil, nil, field); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case *parser.List:
+		if item.BaseItem, err = replace(item.BaseItem); err != nil {
+			return nil, err
+		}
+	case *parser.Map:
+		if item.Key, err = replace(item.Key); err != nil {
+			return nil, err
+		}
+
+		if item.Value, err = replace(item.Value); err != nil {
+			return nil, err
+		}
+	case *parser.Function:
+		for idx := range item.Params {
+			if item.Params[idx], err = replace(item.Params[idx]); err != nil {
+				return nil, err
+			}
+		}
+
+		for idx := range item.Returns {
+			if item.Returns[idx], err = replace(item.Returns[idx]); err != nil {
+				return nil, err
+			}
+		}
+	case *parser.Union:
+		for idx := range item.Variants {
+			if item.Variants[idx].Item, err = replace(item.Variants[idx].Item); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return item, nil
+}
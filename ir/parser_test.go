@@ -0,0 +1,204 @@
+package ir_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2"
+	"go.trulyao.dev/mirror/v2/config"
+	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+func Test_ExportAndGenerateFromIR(t *testing.T) {
+	target := typescript.DefaultConfig()
+
+	original := mirror.New(config.Config{Enabled: true})
+	original.AddUnion((*Shape)(nil), "kind", parser.VariantOf("circle", Circle{}), parser.VariantOf("square", Square{}))
+	original.AddSources(Circle{}, Square{}, Drawing{})
+
+	var exported bytes.Buffer
+	if err := original.ExportIR(&exported); err != nil {
+		t.Fatalf("failed to export IR: %v", err)
+	}
+
+	doc, err := ir.Read(&exported)
+	if err != nil {
+		t.Fatalf("failed to read IR: %v", err)
+	}
+
+	if doc.Version != ir.Version || len(doc.Items) != 4 {
+		t.Fatalf("expected version %d with 4 items, got version %d with %d items", ir.Version, doc.Version, len(doc.Items))
+	}
+
+	fromIR := mirror.New(config.Config{Enabled: true}, ir.NewParser(doc))
+
+	var expected, got strings.Builder
+	if err = original.GenerateTo(&expected, target); err != nil {
+		t.Fatalf("failed to generate from Go types: %v", err)
+	}
+
+	if err = fromIR.GenerateTo(&got, target); err != nil {
+		t.Fatalf("failed to generate from IR: %v", err)
+	}
+
+	if expected.String() != got.String() {
+		t.Errorf("expected code generated from IR to match, expected:\n%s\ngot:\n%s", expected.String(), got.String())
+	}
+}
+
+func Test_IRParserOrder(t *testing.T) {
+	target := typescript.DefaultConfig()
+
+	original := mirror.New(config.Config{Enabled: true})
+	original.AddUnion((*Shape)(nil), "kind", parser.VariantOf("circle", Circle{}), parser.VariantOf("square", Square{}))
+	original.AddSources(Square{}, Drawing{}, Circle{})
+
+	var exported bytes.Buffer
+	if err := original.ExportIR(&exported); err != nil {
+		t.Fatalf("failed to export IR: %v", err)
+	}
+
+	doc, err := ir.Read(&exported)
+	if err != nil {
+		t.Fatalf("failed to read IR: %v", err)
+	}
+
+	// The IR is exported in registration order, the order and field sorting are applied when generating from it
+	original.SetOrder(parser.OrderAlphabetical).SetSortFields(true)
+	fromIR := mirror.New(config.Config{Enabled: true, Order: parser.OrderAlphabetical, SortFields: true}, ir.NewParser(doc))
+
+	var expected, got strings.Builder
+	if err = original.GenerateTo(&expected, target); err != nil {
+		t.Fatalf("failed to generate from Go types: %v", err)
+	}
+
+	if err = fromIR.GenerateTo(&got, target); err != nil {
+		t.Fatalf("failed to generate from IR: %v", err)
+	}
+
+	if expected.String() != got.String() {
+		t.Errorf("expected code generated from IR to match, expected:\n%s\ngot:\n%s", expected.String(), got.String())
+	}
+
+	if idx := strings.Index(got.String(), "export type Circle"); idx == -1 || idx > strings.Index(got.String(), "export type Drawing") {
+		t.Errorf("expected Circle to be generated before Drawing, got:\n%s", got.String())
+	}
+}
+
+func Test_IRExtendEmbeddedTypes(t *testing.T) {
+	target := typescript.DefaultConfig().SetPreferInterface(true).SetExtendEmbeddedTypes(true)
+
+	original := mirror.New(config.Config{Enabled: true, FlattenEmbeddedTypes: true})
+	original.AddSources(Base{}, Account{})
+
+	var exported bytes.Buffer
+	if err := original.ExportIR(&exported); err != nil {
+		t.Fatalf("failed to export IR: %v", err)
+	}
+
+	doc, err := ir.Read(&exported)
+	if err != nil {
+		t.Fatalf("failed to read IR: %v", err)
+	}
+
+	// Every promoted field is decoded with its own copy of the embedded struct, which must still be extended only once
+	fromIR := mirror.New(config.Config{Enabled: true, FlattenEmbeddedTypes: true}, ir.NewParser(doc))
+
+	var expected, got strings.Builder
+	if err = original.GenerateTo(&expected, target); err != nil {
+		t.Fatalf("failed to generate from Go types: %v", err)
+	}
+
+	if err = fromIR.GenerateTo(&got, target); err != nil {
+		t.Fatalf("failed to generate from IR: %v", err)
+	}
+
+	if expected.String() != got.String() {
+		t.Errorf("expected code generated from IR to match, expected:\n%s\ngot:\n%s", expected.String(), got.String())
+	}
+
+	if !strings.Contains(got.String(), "export interface Account extends Base {") {
+		t.Errorf("expected Account to extend Base once, got:\n%s", got.String())
+	}
+}
+
+func Test_ReadInvalidDocument(t *testing.T) {
+	tests := []struct {
+		Description string
+		Source      string
+	}{
+		{Description: "invalid JSON", Source: "{"},
+		{Description: "missing version", Source: `{"items": []}`},
+		{Description: "future version", Source: `{"version": 1000, "items": []}`},
+	}
+
+	for _, test := range tests {
+		if _, err := ir.Read(strings.NewReader(test.Source)); err == nil {
+			t.Errorf("[%s] expected error, got nil", test.Description)
+		}
+	}
+}
+
+func Test_IRParser(t *testing.T) {
+	doc, err := ir.NewDocument(
+		&parser.Struct{
+			ItemName: "User",
+			Fields: []parser.Field{
+				{ItemName: "id", BaseItem: &parser.Scalar{ItemName: "UUID", ItemType: parser.TypeString}},
+				{ItemName: "name", BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}},
+			},
+		},
+		&parser.List{ItemName: "Names", Length: parser.EmptyLength, BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := ir.NewParser(doc)
+	if err = p.AddSource(reflect.TypeOf("")); !errors.Is(err, ir.ErrUnsupported) {
+		t.Errorf("expected adding sources to be unsupported, got %v", err)
+	}
+
+	uuid := &parser.Scalar{ItemName: "UUID", ItemType: parser.TypeString, PkgPath: "github.com/google/uuid"}
+	if err = p.AddCustomType("UUID", uuid); err != nil {
+		t.Fatal(err)
+	}
+
+	var fields []string
+	p.OnParseField(func(_ *reflect.Type, _ *reflect.StructField, field *parser.Field) error {
+		fields = append(fields, field.ItemName)
+		return nil
+	})
+
+	user, ok := p.LookupByName("User")
+	if !ok {
+		t.Fatalf("expected to find `User`")
+	}
+
+	if id, _ := user.(*parser.Struct).GetField("id"); id.BaseItem != uuid {
+		t.Errorf("expected custom type to replace `UUID`, got %#v", id.BaseItem)
+	}
+
+	if strings.Join(fields, ",") != "id,name" {
+		t.Errorf("expected field hook to run for every field, got %v", fields)
+	}
+
+	if p.Count() != 2 {
+		t.Errorf("expected 2 items, got %d", p.Count())
+	}
+
+	for !p.Done() {
+		if _, err = p.Next(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err = p.ParseN(0); err == nil {
+		t.Errorf("expected error after consuming all items")
+	}
+}
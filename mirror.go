@@ -1,28 +1,39 @@
 package mirror
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 
 	"go.trulyao.dev/mirror/v2/config"
+	"go.trulyao.dev/mirror/v2/endpoint"
 	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/output"
 	"go.trulyao.dev/mirror/v2/parser"
 	"go.trulyao.dev/mirror/v2/types"
+	"go.trulyao.dev/mirror/v2/watch"
 )
 
 type Mirror struct {
-	parser types.ParserInterface
-	config config.Config
+	parser    types.ParserInterface
+	config    config.Config
+	output    output.Sink
+	endpoints []endpoint.Endpoint
 }
 
 var (
 	ErrNoSources        = errors.New("no sources provided")
 	ErrNoTargetsDefined = errors.New("no targets provided, at least one target must be defined")
+	ErrNestedWatch      = errors.New("already running under a watcher, the generator command must not start another one")
 )
 
 // New() returns a new instance of the Mirror struct
@@ -34,7 +45,7 @@ func New(mirrorConfig config.Config, optionalParser ...types.ParserInterface) *M
 		p = optionalParser[0]
 	}
 
-	m := &Mirror{config: mirrorConfig}
+	m := &Mirror{config: mirrorConfig, output: output.NewOS()}
 	m.SetParser(p)
 
 	return m
@@ -50,6 +61,22 @@ func (m *Mirror) Config() config.Config {
 	return m.config
 }
 
+// Output() returns the sink generated files are written to
+func (m *Mirror) Output() output.Sink {
+	return m.output
+}
+
+// SetOutput() overrides the sink generated files are written to (the real filesystem by default), for example with an in-memory sink
+func (m *Mirror) SetOutput(sink output.Sink) *Mirror {
+	if sink == nil {
+		slog.Error("output sink cannot be nil")
+		return m
+	}
+
+	m.output = sink
+	return m
+}
+
 // Count() returns the number of sources to generate code for
 func (m *Mirror) Count() int {
 	return m.parser.Count()
@@ -67,6 +94,54 @@ func (m *Mirror) AddSource(s any) *Mirror {
 	return m
 }
 
+// AddSourceWithDefaults() adds a struct source and uses the values of its fields as their default values (e.g. `m.AddSourceWithDefaults(NewConfig())`)
+// The parser must support defaults (see `types.DefaultsParser`), the built-in parser does
+func (m *Mirror) AddSourceWithDefaults(s any) *Mirror {
+	p, ok := m.parser.(types.DefaultsParser)
+	if !ok {
+		slog.Error("the parser does not support default values", slog.String("parser", fmt.Sprintf("%T", m.parser)))
+		return m
+	}
+
+	if err := p.AddSourceWithDefaults(s); err != nil {
+		slog.Error("failed to add source with defaults", slog.String("error", err.Error()))
+	}
+
+	return m
+}
+
+// SetParamNames() registers the names of the parameters of a function type (e.g. `m.SetParamNames(GetUser(nil), "ctx", "request")`), the function is a value of the function type
+// Go does not keep the names of parameters at runtime, so they are generated as `arg0`, `arg1`, etc. otherwise. The parser must support parameter names (see `types.ParamNamesParser`), the built-in parser does
+func (m *Mirror) SetParamNames(fn any, names ...string) *Mirror {
+	p, ok := m.parser.(types.ParamNamesParser)
+	if !ok {
+		slog.Error("the parser does not support parameter names", slog.String("parser", fmt.Sprintf("%T", m.parser)))
+		return m
+	}
+
+	if err := p.SetParamNames(reflect.TypeOf(fn), names...); err != nil {
+		slog.Error("failed to set parameter names", slog.String("error", err.Error()))
+	}
+
+	return m
+}
+
+// AddEnum() adds an enum source along with all of its values (e.g. `m.AddEnum(StatusActive, StatusInactive)`), enums must be string or integer types
+// Go does not keep track of the constants of a type at runtime, so enums are generated as their underlying types otherwise. The parser must support enums (see `types.EnumParser`), the built-in parser does
+func (m *Mirror) AddEnum(values ...any) *Mirror {
+	p, ok := m.parser.(types.EnumParser)
+	if !ok {
+		slog.Error("the parser does not support enums", slog.String("parser", fmt.Sprintf("%T", m.parser)))
+		return m
+	}
+
+	if err := p.AddEnum(values...); err != nil {
+		slog.Error("failed to add enum", slog.String("error", err.Error()))
+	}
+
+	return m
+}
+
 // AddSources() adds multiple sources to the list of sources to generate code for
 func (m *Mirror) AddSources(s ...any) *Mirror {
 	for _, source := range s {
@@ -76,6 +151,88 @@ func (m *Mirror) AddSources(s ...any) *Mirror {
 	return m
 }
 
+// AddUnion() registers the implementations of an interface as variants of a discriminated union and adds the interface as a source
+// The interface should be passed in as a nil pointer to the interface type, for example:
+//
+//	m.AddUnion((*Event)(nil), "type", parser.VariantOf("created", EventCreated{}), parser.VariantOf("deleted", EventDeleted{}))
+func (m *Mirror) AddUnion(iface any, discriminator string, variants ...parser.VariantSource) *Mirror {
+	source := reflect.TypeOf(iface)
+	if source != nil && source.Kind() == reflect.Pointer && source.Elem().Kind() == reflect.Interface {
+		source = source.Elem()
+	}
+
+	p, ok := m.parser.(types.UnionParser)
+	if !ok {
+		slog.Error("the parser does not support unions", slog.String("parser", fmt.Sprintf("%T", m.parser)))
+		return m
+	}
+
+	if err := p.AddUnion(source, discriminator, variants...); err != nil {
+		slog.Error("failed to register union", slog.String("error", err.Error()))
+		return m
+	}
+
+	m.parser.AddSource(source)
+	return m
+}
+
+// AddEndpoint() registers an HTTP endpoint for the targets that generate clients (e.g. `typescript.NewClient`), the request and response are values of their types (e.g. `CreateUserRequest{}`) or nil
+// The request and response types are added as sources unless they have already been added, for example:
+//
+//	m.AddEndpoint("GET", "/users/{id}", nil, User{}).AddEndpoint("POST", "/users", CreateUserRequest{}, User{})
+func (m *Mirror) AddEndpoint(method, path string, request, response any) *Mirror {
+	e, err := endpoint.New(method, path, request, response)
+	if err != nil {
+		slog.Error("failed to register endpoint", slog.String("error", err.Error()))
+		return m
+	}
+
+	return m.AddEndpoints(e)
+}
+
+// AddEndpoints() registers endpoint descriptors (e.g. to set their names), their request and response types are added as sources unless they have already been added
+func (m *Mirror) AddEndpoints(endpoints ...endpoint.Endpoint) *Mirror {
+	for _, e := range endpoints {
+		if err := e.Validate(); err != nil {
+			slog.Error("failed to register endpoint", slog.String("error", err.Error()))
+			continue
+		}
+
+		m.addEndpointSource(e.Request)
+		m.addEndpointSource(e.Response)
+		m.endpoints = append(m.endpoints, e)
+	}
+
+	return m
+}
+
+// Endpoints() returns the registered endpoints
+func (m *Mirror) Endpoints() []endpoint.Endpoint {
+	return slices.Clone(m.endpoints)
+}
+
+// addEndpointSource adds the named type behind a request or response type (e.g. `User` for `[]*User`) as a source if it has not been added yet
+func (m *Mirror) addEndpointSource(source reflect.Type) {
+	for source != nil && source.Name() == "" {
+		switch source.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			source = source.Elem()
+		default:
+			return
+		}
+	}
+
+	if source == nil || source.PkgPath() == "" {
+		return
+	}
+
+	if _, exists := m.parser.LookupByName(source.Name()); exists {
+		return
+	}
+
+	m.parser.AddSource(source)
+}
+
 // ResetTargets() resets the targets to an empty list
 func (m *Mirror) ResetTargets() *Mirror {
 	m.config.Targets = []types.TargetInterface{}
@@ -96,17 +253,39 @@ func (m *Mirror) AddTarget(t types.TargetInterface) *Mirror {
 
 // SetParser() overrides the default parser with a custom parser or any other parser that implements the ParserInterface
 func (m *Mirror) SetParser(p types.ParserInterface) *Mirror {
-	p.SetConfig(parser.Config{
-		FlattenEmbeddedTypes: m.config.FlattenEmbeddedTypes,
-		EnableCaching:        m.config.EnableParserCache,
-	})
+	p.SetConfig(m.parserConfig())
 
 	m.parser = p
 
 	return m
 }
 
+// SetOrder() sets the order declarations are generated in (registration, alphabetical or topological)
+func (m *Mirror) SetOrder(order parser.Order) *Mirror {
+	m.config.Order = order
+	m.parser.SetConfig(m.parserConfig())
+	return m
+}
+
+// SetSortFields() sets whether or not the fields of structs are sorted alphabetically
+func (m *Mirror) SetSortFields(sort bool) *Mirror {
+	m.config.SortFields = sort
+	m.parser.SetConfig(m.parserConfig())
+	return m
+}
+
+// parserConfig() returns the parser configuration derived from the mirror configuration
+func (m *Mirror) parserConfig() parser.Config {
+	return parser.Config{
+		FlattenEmbeddedTypes: m.config.FlattenEmbeddedTypes,
+		EnableCaching:        m.config.EnableParserCache,
+		Order:                m.config.Order,
+		SortFields:           m.config.SortFields,
+	}
+}
+
 // GenerateAndSaveAll() generates code for all sources and saves them to the target files
+// When run by a watcher that knows which packages changed, targets whose sources are not affected by the changes are skipped
 func (m *Mirror) GenerateAndSaveAll() error {
 	if !m.config.Enabled {
 		return nil
@@ -121,12 +300,14 @@ func (m *Mirror) GenerateAndSaveAll() error {
 	}
 
 	for _, target := range m.config.Targets {
-		var (
-			code string
-			err  error
-		)
+		if !m.isAffected(target) {
+			reportSkipped(target)
+			continue
+		}
 
-		if code, err = m.GenerateforTarget(target); err != nil {
+		files, err := m.GenerateFilesForTarget(target)
+		if err != nil {
+			reportTarget(target, nil, err)
 			slog.Error(
 				fmt.Sprintf("failed to generate `%s` code", target.Language()),
 				slog.String("error", err.Error()),
@@ -135,11 +316,12 @@ func (m *Mirror) GenerateAndSaveAll() error {
 			)
 		}
 
-		if code == "" {
+		if len(files) == 0 {
 			continue
 		}
 
-		if err = m.SaveToFile(target, code); err != nil {
+		changed, err := m.SaveFiles(target, files)
+		if err != nil {
 			slog.Error(
 				fmt.Sprintf("failed to save `%s` code", target.Language()),
 				slog.String("error", err.Error()),
@@ -147,11 +329,117 @@ func (m *Mirror) GenerateAndSaveAll() error {
 				slog.String("path", target.Path()),
 			)
 		}
+
+		reportTarget(target, changed, err)
 	}
 
 	return nil
 }
 
+// GenerateAndSave() generates code for all sources, saves them to the target files and returns the paths of the files that actually changed
+// Unlike GenerateAndSaveAll(), the errors of the individual targets are returned (joined) instead of being logged, targets are skipped the same way
+func (m *Mirror) GenerateAndSave() ([]string, error) {
+	if !m.config.Enabled {
+		return nil, nil
+	}
+
+	if m.Count() == 0 {
+		return nil, ErrNoSources
+	}
+
+	if len(m.config.Targets) == 0 {
+		return nil, ErrNoTargetsDefined
+	}
+
+	var (
+		changed []string
+		errs    []error
+	)
+
+	for _, target := range m.config.Targets {
+		if !m.isAffected(target) {
+			reportSkipped(target)
+			continue
+		}
+
+		files, err := m.GenerateFilesForTarget(target)
+		if err != nil {
+			reportTarget(target, nil, err)
+			errs = append(errs, fmt.Errorf("failed to generate `%s` code for `%s`: %w", target.Language(), target.ID(), err))
+			continue
+		}
+
+		changedFiles, err := m.SaveFiles(target, files)
+		changed = append(changed, changedFiles...)
+		reportTarget(target, changedFiles, err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to save `%s` code for `%s`: %w", target.Language(), target.ID(), err))
+		}
+	}
+
+	return changed, errors.Join(errs...)
+}
+
+// Watch() watches the packages backing the registered sources (and the types they reference) and re-runs the generator `command` whenever they change, until the context is cancelled
+// Go types cannot be reloaded in a running program, so the command should be the program that generates the types (e.g. `go run ./cmd/gen`), it is run once before watching starts
+// The packages that changed are passed on to the command, so that GenerateAndSave() and GenerateAndSaveAll() only regenerate the targets whose sources are affected
+// Watch() returns ErrNestedWatch when called from a command run by a watcher, so that a generator that also watches does not start a watcher on every run
+func (m *Mirror) Watch(ctx context.Context, command ...string) error {
+	if os.Getenv(watch.EnvWatch) != "" {
+		return ErrNestedWatch
+	}
+
+	if len(command) == 0 {
+		return errors.New("no generator command provided")
+	}
+
+	pkgPaths := make(map[string]struct{})
+	if err := m.parser.Iterate(func(item parser.Item) error {
+		collectPackages(item, pkgPaths, make(map[parser.Item]struct{}))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(pkgPaths) == 0 {
+		return ErrNoSources
+	}
+
+	importPaths := make([]string, 0, len(pkgPaths))
+	for pkgPath := range pkgPaths {
+		importPaths = append(importPaths, pkgPath)
+	}
+	slices.Sort(importPaths)
+
+	packages, err := watch.ResolvePackages(ctx, importPaths...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		dirs      = make([]string, 0, len(packages))
+		dirToPkgs = make(map[string]string, len(packages))
+	)
+	for _, pkg := range packages {
+		dirs = append(dirs, pkg.Dir)
+		dirToPkgs[pkg.Dir] = pkg.ImportPath
+	}
+
+	watch.Regenerate(ctx, os.Stderr, nil, command...)
+
+	return watch.New(watch.Options{}, dirs...).Run(ctx, func(changed []string) {
+		changedPackages := make([]string, 0, len(changed))
+		for _, file := range changed {
+			if pkg, ok := dirToPkgs[filepath.Dir(file)]; ok && !slices.Contains(changedPackages, pkg) {
+				changedPackages = append(changedPackages, pkg)
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "mirror: change detected in %s, regenerating\n", strings.Join(changedPackages, ", "))
+		watch.Regenerate(ctx, os.Stderr, changedPackages, command...)
+	})
+}
+
 // GenerateforTarget generates code for a single target returning the fully generated code and an error if any
 func (m *Mirror) GenerateforTarget(target types.TargetInterface) (string, error) {
 	if !m.config.Enabled {
@@ -162,24 +450,67 @@ func (m *Mirror) GenerateforTarget(target types.TargetInterface) (string, error)
 		return "", err
 	}
 
-	dirStat, err := os.Stat(target.Path())
+	if err := m.checkOutputPath(target); err != nil {
+		return "", err
+	}
+
+	return m.generate(target)
+}
+
+// GenerateTo renders the code for a single target to the writer (e.g. an HTTP response), the target's output path is never checked or written to
+// The code is always rendered as a single file, regardless of how the target is configured to split its output
+func (m *Mirror) GenerateTo(w io.Writer, target types.TargetInterface) error {
+	if !m.config.Enabled {
+		return nil
+	}
+
+	if err := target.Validate(); err != nil {
+		return err
+	}
+
+	code, err := m.generate(target)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return "", errors.New("output path does not exist")
-		}
+		return err
+	}
 
-		return "", err
+	_, err = io.WriteString(w, code)
+	return err
+}
+
+// ExportIR writes the parsed sources as a versioned IR document (JSON) to the writer, the document can be read back in with `ir.Read` and `ir.NewParser` to generate code without the Go types
+func (m *Mirror) ExportIR(w io.Writer) error {
+	var items []parser.Item
+	if err := m.parser.Iterate(func(item parser.Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	if !dirStat.IsDir() {
-		return "", errors.New("output path is not a directory")
+	doc, err := ir.NewDocument(items...)
+	if err != nil {
+		return err
 	}
 
+	return doc.Write(w)
+}
+
+// ExportEndpoints writes the registered endpoints as a versioned manifest (JSON) to the writer, the types they reference can be exported with `ExportIR`
+func (m *Mirror) ExportEndpoints(w io.Writer) error {
+	return endpoint.NewManifest(m.endpoints...).Write(w)
+}
+
+// generate generates the code for a single target as a single file
+func (m *Mirror) generate(target types.TargetInterface) (string, error) {
 	gen := target.Generator()
-	if err = gen.SetParser(m.parser); err != nil {
+	if err := gen.SetParser(m.parser); err != nil {
 		return "", err
 	}
 
+	if gen, ok := gen.(types.EndpointGenerator); ok {
+		gen.SetEndpoints(m.endpoints)
+	}
+
 	generatedTypes, err := gen.GenerateAll()
 	if err != nil {
 		return "", err
@@ -188,6 +519,38 @@ func (m *Mirror) GenerateforTarget(target types.TargetInterface) (string, error)
 	return target.Header() + "\n" + strings.Join(generatedTypes, "\n\n"), nil
 }
 
+// GenerateFilesForTarget generates code for a single target and returns the generated files (relative to the target's path)
+// Targets whose generators do not implement `types.MultiFileGenerator` always produce a single file
+func (m *Mirror) GenerateFilesForTarget(target types.TargetInterface) ([]types.File, error) {
+	if !m.config.Enabled {
+		return nil, nil
+	}
+
+	gen, ok := target.Generator().(types.MultiFileGenerator)
+	if !ok {
+		code, err := m.GenerateforTarget(target)
+		if err != nil {
+			return nil, err
+		}
+
+		return []types.File{{Name: target.Name(), Content: code}}, nil
+	}
+
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := m.checkOutputPath(target); err != nil {
+		return nil, err
+	}
+
+	if err := gen.SetParser(m.parser); err != nil {
+		return nil, err
+	}
+
+	return gen.GenerateFiles()
+}
+
 // GenerateN generates code for the nth element in the parsed items list
 func (m *Mirror) GenerateN(target types.TargetInterface, n int) (string, error) {
 	if !m.config.Enabled {
@@ -203,27 +566,182 @@ func (m *Mirror) GenerateN(target types.TargetInterface, n int) (string, error)
 		return "", nil
 	}
 
+	if gen, ok := gen.(types.EndpointGenerator); ok {
+		gen.SetEndpoints(m.endpoints)
+	}
+
 	return gen.GenerateN(n)
 }
 
-// SaveToFile saves the generated code to the target file
+// SaveToFile saves the generated code to the target file using the output sink, the file is left untouched if its content has not changed
 func (m *Mirror) SaveToFile(target types.TargetInterface, code string) error {
-	file, err := os.Create(path.Join(target.Path(), target.Name()))
+	_, err := m.output.WriteFile(path.Join(target.Path(), target.Name()), code)
+	return err
+}
+
+// SaveFiles saves the generated files to the target's path using the output sink and returns the paths of the files that actually changed
+// With the default (filesystem) sink, files are written atomically and files whose content has not changed are left untouched, so file watchers are not triggered needlessly
+func (m *Mirror) SaveFiles(target types.TargetInterface, files []types.File) ([]string, error) {
+	var changedFiles []string
+
+	for _, file := range files {
+		filePath := path.Join(target.Path(), file.Name)
+
+		changed, err := m.output.WriteFile(filePath, file.Content)
+		if err != nil {
+			return changedFiles, err
+		}
+
+		if changed {
+			changedFiles = append(changedFiles, filePath)
+		}
+	}
+
+	return changedFiles, nil
+}
+
+// reportTarget prints concise diagnostics for a target when running under `mirror watch` (or `Mirror.Watch`)
+func reportTarget(target types.TargetInterface, changed []string, err error) {
+	if os.Getenv(watch.EnvWatch) == "" {
+		return
+	}
+
+	switch {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "mirror: %s (%s): %s\n", target.Language(), target.ID(), err)
+	case len(changed) == 0:
+		fmt.Fprintf(os.Stderr, "mirror: %s (%s): unchanged\n", target.Language(), target.ID())
+	default:
+		fmt.Fprintf(os.Stderr, "mirror: %s (%s): %d file(s) changed\n", target.Language(), target.ID(), len(changed))
+	}
+}
+
+// reportSkipped prints that a target was skipped by a watcher run because none of its sources changed
+func reportSkipped(target types.TargetInterface) {
+	fmt.Fprintf(os.Stderr, "mirror: %s (%s): skipped, its sources are unaffected\n", target.Language(), target.ID())
+}
+
+// isAffected checks if a target has to be regenerated, i.e. the changed packages are unknown (e.g. when not running under a watcher) or one of them backs the target's sources
+// Types declared in package main are matched against the running program's main package, targets are always regenerated if it is unknown
+func (m *Mirror) isAffected(target types.TargetInterface) bool {
+	changedPackages, ok := watch.ChangedPackages()
+	if !ok {
+		return true
+	}
+
+	pkgPaths, err := m.targetPackages(target)
 	if err != nil {
-		return err
+		return true
 	}
-	defer file.Close()
 
-	if _, err = file.WriteString(code); err != nil {
-		return err
+	mainPackage := watch.MainPackage()
+	for pkgPath := range pkgPaths {
+		if pkgPath == "main" {
+			if mainPackage == "" {
+				return true
+			}
+
+			pkgPath = mainPackage
+		}
+
+		if slices.Contains(changedPackages, pkgPath) {
+			return true
+		}
 	}
 
-	return nil
+	return false
+}
+
+// targetPackages returns the packages backing the sources a target generates code for, clients only depend on the request and response types of the endpoints
+func (m *Mirror) targetPackages(target types.TargetInterface) (map[string]struct{}, error) {
+	var (
+		pkgPaths = make(map[string]struct{})
+		visited  = make(map[parser.Item]struct{})
+	)
+
+	if _, ok := target.Generator().(types.EndpointGenerator); ok {
+		for _, e := range m.endpoints {
+			for _, source := range []reflect.Type{e.Request, e.Response} {
+				if source == nil {
+					continue
+				}
+
+				item, err := m.parser.Parse(source)
+				if err != nil {
+					return nil, err
+				}
+
+				collectPackages(item, pkgPaths, visited)
+			}
+		}
+
+		return pkgPaths, nil
+	}
+
+	err := m.parser.Iterate(func(item parser.Item) error {
+		collectPackages(item, pkgPaths, visited)
+		return nil
+	})
+
+	return pkgPaths, err
+}
+
+// collectPackages collects the packages of an item and every item it references
+func collectPackages(item parser.Item, pkgPaths map[string]struct{}, visited map[parser.Item]struct{}) {
+	if item == nil {
+		return
+	}
+
+	if _, ok := visited[item]; ok {
+		return
+	}
+	visited[item] = struct{}{}
+
+	if pkgPath := parser.PackagePath(item); pkgPath != "" {
+		pkgPaths[pkgPath] = struct{}{}
+	}
+
+	switch item := item.(type) {
+	case *parser.Struct:
+		for _, field := range item.Fields {
+			collectPackages(field.BaseItem, pkgPaths, visited)
+		}
+	case *parser.List:
+		collectPackages(item.BaseItem, pkgPaths, visited)
+	case *parser.Map:
+		collectPackages(item.Key, pkgPaths, visited)
+		collectPackages(item.Value, pkgPaths, visited)
+	case *parser.Function:
+		for _, param := range item.Params {
+			collectPackages(param, pkgPaths, visited)
+		}
+
+		for _, ret := range item.Returns {
+			collectPackages(ret, pkgPaths, visited)
+		}
+	case *parser.Union:
+		for _, variant := range item.Variants {
+			collectPackages(variant.Item, pkgPaths, visited)
+		}
+	}
+}
+
+// checkOutputPath ensures the target's output path exists in the output sink, the path is created if it does not exist and `CreateMissingDirectories` is enabled
+func (m *Mirror) checkOutputPath(target types.TargetInterface) error {
+	return m.output.CheckDir(target.Path(), m.config.CreateMissingDirectories)
 }
 
 // Check that all built-in implementations match the interface types
 var (
 	_ types.ParserInterface    = &parser.Parser{}
+	_ types.ParserInterface    = &ir.Parser{}
+	_ types.UnionParser        = &parser.Parser{}
+	_ types.DefaultsParser     = &parser.Parser{}
+	_ types.ParamNamesParser   = &parser.Parser{}
+	_ types.EnumParser         = &parser.Parser{}
 	_ types.TargetInterface    = &typescript.Config{}
 	_ types.GeneratorInterface = &typescript.Generator{}
+	_ types.MultiFileGenerator = &typescript.Generator{}
+	_ types.TargetInterface    = &typescript.ClientConfig{}
+	_ types.EndpointGenerator  = &typescript.ClientGenerator{}
 )
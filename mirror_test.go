@@ -0,0 +1,302 @@
+package mirror_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.trulyao.dev/mirror/v2"
+	"go.trulyao.dev/mirror/v2/config"
+	"go.trulyao.dev/mirror/v2/extractor/meta"
+	"go.trulyao.dev/mirror/v2/generator/typescript"
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/output"
+	"go.trulyao.dev/mirror/v2/parser"
+	"go.trulyao.dev/mirror/v2/route"
+	"go.trulyao.dev/mirror/v2/watch"
+)
+
+type Person struct {
+	Name string `json:"name"`
+}
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+func Test_GenerateAndSave(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "types")
+	filePath := filepath.Join(outputPath, "generated.ts")
+
+	m := mirror.New(config.Config{Enabled: true, CreateMissingDirectories: true})
+	m.AddSource(Person{})
+	m.AddTarget(typescript.DefaultConfig().SetOutputPath(outputPath))
+
+	changed, err := m.GenerateAndSave()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(changed, []string{filePath}) {
+		t.Fatalf("expected %v to have changed, got %v", []string{filePath}, changed)
+	}
+
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("expected generated file to exist: %v", err)
+	}
+
+	changed, err = m.GenerateAndSave()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(changed) != 0 {
+		t.Errorf("expected no files to have changed, got %v", changed)
+	}
+
+	if newStat, _ := os.Stat(filePath); !newStat.ModTime().Equal(stat.ModTime()) {
+		t.Errorf("expected unchanged file to be left untouched")
+	}
+
+	entries, _ := os.ReadDir(outputPath)
+	if len(entries) != 1 {
+		t.Errorf("expected only the generated file in the output path, got %d entries", len(entries))
+	}
+}
+
+func Test_GenerateAndSaveAffectedTargets(t *testing.T) {
+	var (
+		typesPath  = filepath.Join(t.TempDir(), "types")
+		clientPath = filepath.Join(t.TempDir(), "client")
+	)
+
+	m := mirror.New(config.Config{Enabled: true, CreateMissingDirectories: true})
+	m.AddSource(Person{}).AddEndpoint("GET", "/constraints", nil, meta.Constraints{})
+	m.AddTarget(typescript.DefaultConfig().SetOutputPath(typesPath))
+	m.AddTarget(typescript.NewClient(typescript.DefaultConfig()).SetOutputPath(clientPath))
+
+	t.Setenv(watch.EnvWatch, "1")
+
+	tests := []struct {
+		Description     string
+		ChangedPackages string
+		Expect          []string
+	}{
+		{Description: "unrelated package changed", ChangedPackages: "example.com/unrelated", Expect: nil},
+		{Description: "package of the sources changed", ChangedPackages: reflect.TypeOf(Person{}).PkgPath(), Expect: []string{filepath.Join(typesPath, "generated.ts")}},
+		{Description: "package of the endpoint types changed", ChangedPackages: "example.com/unrelated," + reflect.TypeOf(meta.Constraints{}).PkgPath(), Expect: []string{filepath.Join(clientPath, "client.ts")}},
+	}
+
+	for _, test := range tests {
+		t.Setenv(watch.EnvChangedPackages, test.ChangedPackages)
+
+		changed, err := m.GenerateAndSave()
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %v", test.Description, err)
+		}
+
+		if !slices.Equal(changed, test.Expect) {
+			t.Errorf("[%s] expected %v to have changed, got %v", test.Description, test.Expect, changed)
+		}
+	}
+}
+
+func Test_GenerateAndSaveMissingDirectory(t *testing.T) {
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddSource(Person{})
+	m.AddTarget(typescript.DefaultConfig().SetOutputPath(filepath.Join(t.TempDir(), "missing")))
+
+	if _, err := m.GenerateAndSave(); err == nil {
+		t.Errorf("expected missing output path to fail without `CreateMissingDirectories`")
+	}
+}
+
+func Test_WatchNested(t *testing.T) {
+	t.Setenv(watch.EnvWatch, "1")
+
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddSource(Person{})
+
+	// The command would fail if it was run, the nested watcher must return before running it
+	if err := m.Watch(context.Background(), "false"); !errors.Is(err, mirror.ErrNestedWatch) {
+		t.Errorf("expected %v, got %v", mirror.ErrNestedWatch, err)
+	}
+}
+
+func Test_WatchMainPackage(t *testing.T) {
+	// Types declared in package main have `main` as their package path, which the go tool cannot resolve as it is
+	doc, err := ir.NewDocument(&parser.Struct{
+		ItemName: "Config",
+		PkgPath:  "main",
+		Fields:   []parser.Field{{ItemName: "port", BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+
+	m := mirror.New(config.Config{Enabled: true}, ir.NewParser(doc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if err := m.Watch(ctx, "true"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_GenerateToMemory(t *testing.T) {
+	sink := output.NewMemory()
+	target := typescript.DefaultConfig().SetOutputPath("types").SetOutputMode(typescript.OutputPerType)
+
+	m := mirror.New(config.Config{Enabled: true}).SetOutput(sink)
+	m.AddSource(Person{})
+	m.AddTarget(target)
+
+	changed, err := m.GenerateAndSave()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"types/index.ts", "types/person.ts"}
+	slices.Sort(changed)
+	if !slices.Equal(changed, expected) || !slices.Equal(sink.Names(), expected) {
+		t.Errorf("expected %v to have been written, got %v (changed: %v)", expected, sink.Names(), changed)
+	}
+
+	var sb strings.Builder
+	if err = m.GenerateTo(&sb, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(sb.String(), "export type Person = {\n    name: string;\n};") {
+		t.Errorf("expected rendered code to contain the Person type, got:\n%s", sb.String())
+	}
+}
+
+func Test_SetOrder(t *testing.T) {
+	type Team struct {
+		Name    string   `json:"name"`
+		Members []Person `json:"members"`
+	}
+
+	generate := func(sources ...any) string {
+		m := mirror.New(config.Config{Enabled: true}).SetOrder(parser.OrderTopological).SetSortFields(true)
+		m.AddSources(sources...)
+
+		var sb strings.Builder
+		if err := m.GenerateTo(&sb, typescript.DefaultConfig()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return sb.String()
+	}
+
+	code := generate(Team{}, Person{})
+	if other := generate(Person{}, Team{}); code != other {
+		t.Errorf("expected the output to be the same regardless of the order sources are added in, got:\n%s\nand:\n%s", code, other)
+	}
+
+	if !strings.HasSuffix(code, "export type Person = {\n    name: string;\n};\n\nexport type Team = {\n    members: Array<Person>;\n    name: string;\n};") {
+		t.Errorf("expected Person to be generated before Team with sorted fields, got:\n%s", code)
+	}
+}
+
+func Test_AddEnum(t *testing.T) {
+	type Account struct {
+		Status  Status         `json:"status"`
+		History map[Status]int `json:"history"`
+	}
+
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddEnum(StatusActive, StatusInactive)
+	m.AddSource(Account{})
+
+	var sb strings.Builder
+	if err := m.GenerateTo(&sb, typescript.DefaultConfig()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "export type Status = \"active\" | \"inactive\";\n\nexport type Account = {\n    status: Status;\n    history: Partial<Record<Status, number>>;\n};"
+	if !strings.HasSuffix(sb.String(), expected) {
+		t.Errorf("expected rendered code to contain the Status enum and the Account type, got:\n%s", sb.String())
+	}
+}
+
+func Test_AddSourceWithDefaults(t *testing.T) {
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddSourceWithDefaults(Person{Name: "Jane"})
+
+	var sb strings.Builder
+	if err := m.GenerateTo(&sb, typescript.DefaultConfig().SetGenerateDefaults(true)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(sb.String(), "export const defaultPerson: Person = {\n    name: \"Jane\",\n};") {
+		t.Errorf("expected rendered code to contain the default Person, got:\n%s", sb.String())
+	}
+}
+
+func Test_AddEndpoint(t *testing.T) {
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddSource(Person{}).AddEndpoint("GET", "/people/{id}", nil, Person{}).AddEndpoint("POST", "/people", Person{}, []*Person{})
+
+	if count := m.Count(); count != 1 {
+		t.Errorf("expected endpoint types to be added once, got %d sources", count)
+	}
+
+	if endpoints := m.Endpoints(); len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(endpoints))
+	}
+
+	var sb strings.Builder
+	if err := m.GenerateTo(&sb, typescript.NewClient(typescript.DefaultConfig())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, snippet := range []string{
+		`import type { Person } from "./generated";`,
+		"getPeopleById: (params: { id: string | number }): Promise<Person> =>",
+		"postPeople: (body: Person): Promise<Array<Person>> =>",
+	} {
+		if !strings.Contains(sb.String(), snippet) {
+			t.Errorf("expected rendered client to contain %q, got:\n%s", snippet, sb.String())
+		}
+	}
+}
+
+func Test_AddRoutes(t *testing.T) {
+	router := route.New(http.NewServeMux())
+	router.Method("GET", "/people/{id}", route.HandleFunc[route.None, Person](func(http.ResponseWriter, *http.Request) {}))
+
+	m := mirror.New(config.Config{Enabled: true})
+	m.AddEndpoints(router.Endpoints()...)
+
+	var types strings.Builder
+	if err := m.GenerateTo(&types, typescript.DefaultConfig()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(types.String(), "export type Person = {") {
+		t.Errorf("expected rendered types to contain Person, got:\n%s", types.String())
+	}
+
+	var manifest strings.Builder
+	if err := m.ExportEndpoints(&manifest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(manifest.String(), `"name": "getPeopleById"`) || !strings.Contains(manifest.String(), `"response": "mirror_test.Person"`) {
+		t.Errorf("expected manifest to contain the endpoint, got:\n%s", manifest.String())
+	}
+}
@@ -0,0 +1,33 @@
+// Package naming provides the strategies targets can use to transform field and type names (e.g. `FirstName` -> `firstName`)
+package naming
+
+import "go.trulyao.dev/mirror/v2/helper"
+
+// Strategy transforms a Go identifier into the name used in the generated code
+type Strategy func(name string) string
+
+var (
+	// Preserve keeps names as they are
+	Preserve Strategy = func(name string) string { return name }
+
+	// CamelCase converts names to camelCase (e.g. `FirstName` -> `firstName`, `UserID` -> `userId`)
+	CamelCase Strategy = helper.ToCamelCase
+
+	// PascalCase converts names to PascalCase (e.g. `first_name` -> `FirstName`)
+	PascalCase Strategy = helper.ToPascalCase
+
+	// SnakeCase converts names to snake_case (e.g. `FirstName` -> `first_name`)
+	SnakeCase Strategy = helper.ToSnakeCase
+
+	// KebabCase converts names to kebab-case (e.g. `FirstName` -> `first-name`)
+	KebabCase Strategy = helper.ToKebabCase
+)
+
+// Apply applies the strategy to a name, a nil strategy keeps the name as it is
+func (s Strategy) Apply(name string) string {
+	if s == nil {
+		return name
+	}
+
+	return s(name)
+}
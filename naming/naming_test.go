@@ -0,0 +1,34 @@
+package naming_test
+
+import (
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/naming"
+)
+
+func Test_Strategies(t *testing.T) {
+	tests := []struct {
+		Description string
+		Strategy    naming.Strategy
+		Source      string
+		Expect      string
+	}{
+		{Description: "preserve", Strategy: naming.Preserve, Source: "FirstName", Expect: "FirstName"},
+		{Description: "nil strategy", Strategy: nil, Source: "FirstName", Expect: "FirstName"},
+		{Description: "camel case", Strategy: naming.CamelCase, Source: "FirstName", Expect: "firstName"},
+		{Description: "camel case with acronym", Strategy: naming.CamelCase, Source: "UserID", Expect: "userId"},
+		{Description: "camel case from snake case", Strategy: naming.CamelCase, Source: "first_name", Expect: "firstName"},
+		{Description: "pascal case", Strategy: naming.PascalCase, Source: "first_name", Expect: "FirstName"},
+		{Description: "pascal case with leading acronym", Strategy: naming.PascalCase, Source: "HTTPServer", Expect: "HttpServer"},
+		{Description: "snake case", Strategy: naming.SnakeCase, Source: "FirstName", Expect: "first_name"},
+		{Description: "snake case with acronym", Strategy: naming.SnakeCase, Source: "UserID", Expect: "user_id"},
+		{Description: "kebab case", Strategy: naming.KebabCase, Source: "FirstName", Expect: "first-name"},
+		{Description: "custom", Strategy: func(name string) string { return "x" + name }, Source: "Name", Expect: "xName"},
+	}
+
+	for _, test := range tests {
+		if got := test.Strategy.Apply(test.Source); got != test.Expect {
+			t.Errorf("[%s] expected %q, got %q", test.Description, test.Expect, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"path"
+	"slices"
+	"sync"
+)
+
+// Memory keeps generated files in memory, this is useful for serving generated code, test fixtures and testing targets without touching the disk
+// Directories are not tracked, so every directory is considered to exist
+type Memory struct {
+	mu    sync.RWMutex
+	files map[string]string
+}
+
+// NewMemory returns an empty in-memory sink
+func NewMemory() *Memory {
+	return &Memory{files: make(map[string]string)}
+}
+
+func (*Memory) CheckDir(string, bool) error {
+	return nil
+}
+
+func (m *Memory) WriteFile(name string, content string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = path.Clean(name)
+	if existing, ok := m.files[name]; ok && existing == content {
+		return false, nil
+	}
+
+	m.files[name] = content
+	return true, nil
+}
+
+// ReadFile returns the content of a file and whether it exists
+func (m *Memory) ReadFile(name string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	content, ok := m.files[path.Clean(name)]
+	return content, ok
+}
+
+// Names returns the sorted names of all the files written to the sink
+func (m *Memory) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Reset removes all the files from the sink
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.files)
+}
+
+var (
+	_ Sink = &OS{}
+	_ Sink = &Memory{}
+)
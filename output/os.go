@@ -0,0 +1,100 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+var (
+	ErrDirNotExist = errors.New("output path does not exist")
+	ErrNotDir      = errors.New("output path is not a directory")
+)
+
+// OS writes generated files to the real filesystem
+type OS struct{}
+
+// NewOS returns a sink that writes to the real filesystem
+func NewOS() *OS {
+	return &OS{}
+}
+
+func (*OS) CheckDir(dir string, create bool) error {
+	dirStat, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if create {
+				return os.MkdirAll(dir, 0o755)
+			}
+
+			return ErrDirNotExist
+		}
+
+		return err
+	}
+
+	if !dirStat.IsDir() {
+		return ErrNotDir
+	}
+
+	return nil
+}
+
+// WriteFile writes the file atomically (to a temporary file that is synced and renamed once fully written), files whose content has not changed are left untouched so file watchers are not triggered needlessly
+// New files are created with 0644 permissions while existing files keep their permissions
+func (*OS) WriteFile(name string, content string) (bool, error) {
+	existing, err := os.ReadFile(name)
+	if err == nil && string(existing) == content {
+		return false, nil
+	}
+
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	mode := os.FileMode(0o644)
+	if err == nil {
+		info, err := os.Stat(name)
+		if err != nil {
+			return false, err
+		}
+
+		mode = info.Mode().Perm()
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return false, err
+	}
+
+	// The temporary file is created in the same directory to make sure the rename does not cross filesystems
+	tmpFile, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.WriteString(content); err != nil {
+		tmpFile.Close()
+		return false, err
+	}
+
+	// The content has to reach the disk before the rename, otherwise a crash can leave an empty or truncated file behind
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return false, err
+	}
+
+	if err = tmpFile.Close(); err != nil {
+		return false, err
+	}
+
+	if err = os.Chmod(tmpFile.Name(), mode); err != nil {
+		return false, err
+	}
+
+	if err = os.Rename(tmpFile.Name(), name); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,10 @@
+package output
+
+// Sink is where generated files are written to, this makes it possible to write generated code to the real filesystem, memory or anywhere else
+type Sink interface {
+	// CheckDir ensures the directory exists and is a directory, it is created (with its parents) if it does not exist and `create` is true
+	CheckDir(dir string, create bool) error
+
+	// WriteFile writes the content to the file (creating any missing parent directories) if it differs from the existing content and reports whether the file was written
+	WriteFile(name string, content string) (bool, error)
+}
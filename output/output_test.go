@@ -0,0 +1,109 @@
+package output_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/output"
+)
+
+func Test_OSSink(t *testing.T) {
+	sink := output.NewOS()
+	root := t.TempDir()
+	dir := filepath.Join(root, "types")
+
+	if err := sink.CheckDir(dir, false); !errors.Is(err, output.ErrDirNotExist) {
+		t.Errorf("expected %v, got %v", output.ErrDirNotExist, err)
+	}
+
+	if err := sink.CheckDir(dir, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name := filepath.Join(dir, "nested", "generated.ts")
+	tests := []struct {
+		Description string
+		Content     string
+		Changed     bool
+	}{
+		{Description: "write new file", Content: "export type A = string;", Changed: true},
+		{Description: "write unchanged file", Content: "export type A = string;", Changed: false},
+		{Description: "write changed file", Content: "export type A = number;", Changed: true},
+	}
+
+	for _, test := range tests {
+		changed, err := sink.WriteFile(name, test.Content)
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.Description, err)
+			continue
+		}
+
+		if changed != test.Changed {
+			t.Errorf("[%s] expected changed to be %v, got %v", test.Description, test.Changed, changed)
+		}
+
+		if content, _ := os.ReadFile(name); string(content) != test.Content {
+			t.Errorf("[%s] expected %q, got %q", test.Description, test.Content, content)
+		}
+	}
+
+	if err := sink.CheckDir(name, false); !errors.Is(err, output.ErrNotDir) {
+		t.Errorf("expected %v, got %v", output.ErrNotDir, err)
+	}
+
+	// No temporary files should be left behind
+	if entries, _ := os.ReadDir(filepath.Dir(name)); len(entries) != 1 {
+		t.Errorf("expected a single file, got %d entries", len(entries))
+	}
+
+	if stat, _ := os.Stat(name); stat.Mode().Perm() != 0o644 {
+		t.Errorf("expected new files to be created with %v, got %v", os.FileMode(0o644), stat.Mode().Perm())
+	}
+
+	// Existing files keep their permissions when they are rewritten
+	if err := os.Chmod(name, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := sink.WriteFile(name, "export type A = boolean;"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stat, _ := os.Stat(name); stat.Mode().Perm() != 0o600 {
+		t.Errorf("expected the permissions of the existing file (%v) to be kept, got %v", os.FileMode(0o600), stat.Mode().Perm())
+	}
+}
+
+func Test_MemorySink(t *testing.T) {
+	sink := output.NewMemory()
+
+	if err := sink.CheckDir("./does/not/exist", false); err != nil {
+		t.Errorf("expected every directory to exist, got %v", err)
+	}
+
+	if changed, _ := sink.WriteFile("./types/b.ts", "b"); !changed {
+		t.Errorf("expected new file to be written")
+	}
+
+	if changed, _ := sink.WriteFile("types/b.ts", "b"); changed {
+		t.Errorf("expected unchanged file to be skipped")
+	}
+
+	sink.WriteFile("types/a.ts", "a")
+
+	if names := sink.Names(); !slices.Equal(names, []string{"types/a.ts", "types/b.ts"}) {
+		t.Errorf("unexpected file names: %v", names)
+	}
+
+	if content, ok := sink.ReadFile("types/a.ts"); !ok || content != "a" {
+		t.Errorf("expected %q, got %q (exists: %v)", "a", content, ok)
+	}
+
+	sink.Reset()
+	if names := sink.Names(); len(names) != 0 {
+		t.Errorf("expected no files after reset, got %v", names)
+	}
+}
@@ -24,6 +24,8 @@ const (
 
 	TypeFunction Type = "function"
 
+	TypeUnion Type = "union"
+
 	TypeVoid Type = "void"
 	TypeNil  Type = "nil"
 )
@@ -42,11 +44,18 @@ type Field struct {
 	ItemName string
 	BaseItem Item
 	Meta     meta.Meta
+
+	// EmbeddedFrom is the embedded struct the field was promoted from when embedded types are flattened (nil for the struct's own fields)
+	EmbeddedFrom *Struct
 }
 
 // Represents a struct type
 type Struct struct {
 	ItemName string
+
+	// PkgPath is the import path of the package the Go type was declared in (empty for built-in and unnamed types)
+	PkgPath string
+
 	Fields   []Field
 	Nullable bool
 }
@@ -54,8 +63,21 @@ type Struct struct {
 // Represents a scalar type like string, number, boolean, etc.
 type Scalar struct {
 	ItemName string
+	PkgPath  string
 	ItemType Type
 	Nullable bool
+
+	// BitSize is the size (in bits) of numeric types (e.g. 8 for `int8`, 64 for `uint64` and `float64`), it is zero for non-numeric types
+	BitSize int
+
+	// Unsigned is true for unsigned integer types (e.g. `uint32`)
+	Unsigned bool
+
+	// Named is true for scalars declared as their own types (e.g. `type UserID string`) instead of built-in types, targets can use this to tell them apart from their underlying types
+	Named bool
+
+	// Enum is the list of JSON-encoded values (e.g. `"active"` or `1`) of enum types registered with `AddEnum`, it is empty for other scalars
+	Enum []string
 }
 
 // Represents a list type; array or slice
@@ -64,6 +86,7 @@ const EmptyLength = -1 // used to represent a slice
 // Represents a list (array or slice) type
 type List struct {
 	ItemName string
+	PkgPath  string
 	BaseItem Item
 	Nullable bool
 	Length   int // -1 if slice
@@ -72,6 +95,7 @@ type List struct {
 // Represents a map type
 type Map struct {
 	ItemName string
+	PkgPath  string
 	Key      Item
 	Value    Item
 	Nullable bool
@@ -80,11 +104,89 @@ type Map struct {
 // Represents a function
 type Function struct {
 	ItemName string
+	PkgPath  string
 	Params   []Item
+
+	// ParamNames are the names of the parameters (in the same order as Params) if they have been registered, see `Parser.SetParamNames`
+	ParamNames []string
+
 	Returns  []Item
 	Nullable bool
 }
 
+// ParamName returns the name of the nth parameter, empty if the parameter has not been named
+func (f *Function) ParamName(idx int) string {
+	if idx < 0 || idx >= len(f.ParamNames) {
+		return ""
+	}
+
+	return f.ParamNames[idx]
+}
+
+// Represents a single variant of a discriminated union
+type UnionVariant struct {
+	// Tag is the value of the discriminator field that identifies this variant (e.g. "created")
+	Tag  string
+	Item Item
+}
+
+// Represents a discriminated union of the known implementations of an interface (a "sealed" interface)
+type Union struct {
+	ItemName string
+	PkgPath  string
+
+	// Discriminator is the name of the field used to tell the variants apart (e.g. "type")
+	Discriminator string
+
+	Variants []UnionVariant
+	Nullable bool
+}
+
+// QualifiedName returns the fully-qualified name of the Go type an item was parsed from (e.g. `github.com/google/uuid.UUID`)
+// Built-in types (e.g. `string`) are returned as is and an empty string is returned for unnamed types
+func QualifiedName(item Item) string {
+	if item == nil || item.Name() == "" {
+		return ""
+	}
+
+	pkgPath := PackagePath(item)
+	if pkgPath == "" {
+		return item.Name()
+	}
+
+	return pkgPath + "." + item.Name()
+}
+
+// IsContext checks if an item was parsed from `context.Context`
+func IsContext(item Item) bool {
+	return QualifiedName(item) == "context.Context"
+}
+
+// IsError checks if an item was parsed from the built-in `error` interface
+func IsError(item Item) bool {
+	return QualifiedName(item) == "error"
+}
+
+// PackagePath returns the import path of the package the Go type an item was parsed from was declared in (empty for built-in and unnamed types)
+func PackagePath(item Item) string {
+	switch item := item.(type) {
+	case *Scalar:
+		return item.PkgPath
+	case *Struct:
+		return item.PkgPath
+	case *List:
+		return item.PkgPath
+	case *Map:
+		return item.PkgPath
+	case *Function:
+		return item.PkgPath
+	case *Union:
+		return item.PkgPath
+	}
+
+	return ""
+}
+
 // SCALAR
 func (s *Scalar) Name() string {
 	return s.ItemName
@@ -102,6 +204,17 @@ func (s *Scalar) IsNullable() bool {
 	return s.Nullable
 }
 
+// IsInteger reports whether the scalar is an integer type (signed or unsigned)
+func (s *Scalar) IsInteger() bool {
+	return s.ItemType == TypeInteger
+}
+
+// IsLargeInteger reports whether the scalar is an integer that cannot be represented exactly by an IEEE-754 double (i.e. a 64-bit integer)
+// This is useful for targets like Javascript where all numbers are doubles and values above 2^53 silently lose precision
+func (s *Scalar) IsLargeInteger() bool {
+	return s.IsInteger() && s.BitSize >= 64
+}
+
 // STRUCT
 func (s *Struct) Name() string {
 	return s.ItemName
@@ -214,10 +327,40 @@ func (f *Function) IsNullable() bool {
 	return f.Nullable
 }
 
+// UNION
+func (u *Union) Name() string {
+	return u.ItemName
+}
+
+func (u *Union) Type() Type {
+	return TypeUnion
+}
+
+func (u *Union) IsScalar() bool {
+	return false
+}
+
+func (u *Union) IsNullable() bool {
+	return u.Nullable
+}
+
+// Get a variant by its tag (discriminator value)
+// Returns pointer to the variant or nil and a boolean indicating if the variant was found
+func (u *Union) GetVariant(tag string) (*UnionVariant, bool) {
+	for _, v := range u.Variants {
+		if v.Tag == tag {
+			return &v, true
+		}
+	}
+
+	return nil, false
+}
+
 var (
 	_ Item = (*Scalar)(nil)
 	_ Item = (*Struct)(nil)
 	_ Item = (*Map)(nil)
 	_ Item = (*List)(nil)
 	_ Item = (*Function)(nil)
+	_ Item = (*Union)(nil)
 )
@@ -0,0 +1,210 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+)
+
+// Order determines the order parsed sources are iterated (and therefore generated) in
+type Order int
+
+const (
+	// OrderRegistration iterates over sources in the order they were added (default)
+	OrderRegistration Order = iota
+
+	// OrderAlphabetical iterates over sources sorted by name (case-insensitively, ties are broken by the fully-qualified name), so that the output does not depend on the order sources are added in
+	OrderAlphabetical
+
+	// OrderTopological iterates over sources so that every source comes after the sources it references, independent sources (and the members of reference cycles) are sorted alphabetically
+	OrderTopological
+)
+
+// String returns the name of the order
+func (o Order) String() string {
+	switch o {
+	case OrderRegistration:
+		return "registration"
+	case OrderAlphabetical:
+		return "alphabetical"
+	case OrderTopological:
+		return "topological"
+	default:
+		return "unknown"
+	}
+}
+
+// SortItems returns a copy of the items in the given order, items are expected to be in registration order
+func SortItems(items []Item, order Order) []Item {
+	sorted := slices.Clone(items)
+
+	switch order {
+	case OrderAlphabetical:
+		slices.SortStableFunc(sorted, compareItems)
+	case OrderTopological:
+		slices.SortStableFunc(sorted, compareItems)
+		sorted = sortTopologically(sorted)
+	}
+
+	return sorted
+}
+
+// SortFields sorts the fields of every struct in the item (including nested and referenced structs) by the names they are generated with
+func SortFields(item Item) {
+	walkItem(item, make(map[Item]struct{}), func(item Item) bool {
+		if s, ok := item.(*Struct); ok {
+			sortFields(s.Fields)
+		}
+
+		return true
+	})
+}
+
+// sortFields sorts fields by the names they are generated with (case-insensitively, ties are broken by the exact and original names)
+func sortFields(fields []Field) {
+	slices.SortStableFunc(fields, func(a, b Field) int {
+		if c := compareNames(fieldName(a), fieldName(b)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Meta.OriginalName, b.Meta.OriginalName)
+	})
+}
+
+// fieldName returns the name a field is generated with, the name set with struct tags or hooks takes precedence
+func fieldName(field Field) string {
+	if field.Meta.Name != "" {
+		return field.Meta.Name
+	}
+
+	return field.ItemName
+}
+
+// compareItems compares items by name and then by their fully-qualified name
+func compareItems(a, b Item) int {
+	if c := compareNames(a.Name(), b.Name()); c != 0 {
+		return c
+	}
+
+	return strings.Compare(QualifiedName(a), QualifiedName(b))
+}
+
+// compareNames compares names case-insensitively, names that only differ in case are compared byte-wise so that the order is always the same
+func compareNames(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a, b)
+}
+
+// sortTopologically orders (alphabetically sorted) items so that items come after the items they reference, a depth-first search is used so that dependencies are placed right before the first item that needs them
+func sortTopologically(items []Item) []Item {
+	indices := make(map[string]int, len(items))
+	for idx, item := range items {
+		if name := QualifiedName(item); name != "" {
+			if _, exists := indices[name]; !exists {
+				indices[name] = idx
+			}
+		}
+	}
+
+	var (
+		sorted  = make([]Item, 0, len(items))
+		visited = make([]bool, len(items))
+		visit   func(idx int)
+	)
+
+	visit = func(idx int) {
+		if visited[idx] {
+			return
+		}
+		visited[idx] = true
+
+		for _, dep := range dependencies(items[idx], indices) {
+			visit(dep)
+		}
+
+		sorted = append(sorted, items[idx])
+	}
+
+	for idx := range items {
+		visit(idx)
+	}
+
+	return sorted
+}
+
+// dependencies returns the (sorted) indices of the items referenced by an item, the walk stops at referenced items since they have their own dependencies
+func dependencies(item Item, indices map[string]int) []int {
+	var (
+		deps = make(map[int]struct{})
+		self = QualifiedName(item)
+	)
+
+	walkItem(item, make(map[Item]struct{}), func(child Item) bool {
+		name := QualifiedName(child)
+		if name == "" || name == self {
+			return true
+		}
+
+		if idx, ok := indices[name]; ok {
+			deps[idx] = struct{}{}
+			return false
+		}
+
+		return true
+	})
+
+	sorted := make([]int, 0, len(deps))
+	for idx := range deps {
+		sorted = append(sorted, idx)
+	}
+	slices.Sort(sorted)
+
+	return sorted
+}
+
+// walkItem calls `f` on the item and every item it contains (depth-first), the children of an item are skipped when `f` returns false
+func walkItem(item Item, visited map[Item]struct{}, f func(Item) bool) {
+	if item == nil {
+		return
+	}
+
+	if _, ok := visited[item]; ok {
+		return
+	}
+	visited[item] = struct{}{}
+
+	if !f(item) {
+		return
+	}
+
+	switch item := item.(type) {
+	case *Struct:
+		for _, field := range item.Fields {
+			walkItem(field.BaseItem, visited, f)
+
+			// Embedded structs can be referenced by generators (e.g. `interface X extends Embedded`) even when their fields have been flattened
+			if field.EmbeddedFrom != nil {
+				walkItem(field.EmbeddedFrom, visited, f)
+			}
+		}
+	case *List:
+		walkItem(item.BaseItem, visited, f)
+	case *Map:
+		walkItem(item.Key, visited, f)
+		walkItem(item.Value, visited, f)
+	case *Function:
+		for _, param := range item.Params {
+			walkItem(param, visited, f)
+		}
+
+		for _, ret := range item.Returns {
+			walkItem(ret, visited, f)
+		}
+	case *Union:
+		for _, variant := range item.Variants {
+			walkItem(variant.Item, visited, f)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package parser_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/parser"
+)
+
+type (
+	Order struct {
+		Customer Customer  `json:"customer"`
+		Lines    []Line    `json:"lines"`
+		Status   Status    `json:"status"`
+		Parent   *Order    `json:"parent"`
+		Notes    []*string `json:"notes"`
+	}
+
+	Customer struct {
+		Name    string  `json:"name"`
+		Address Address `json:"address"`
+	}
+
+	Address struct {
+		City string `json:"city"`
+	}
+
+	Line struct {
+		SKU      string `json:"sku"`
+		Quantity int    `json:"quantity"`
+	}
+
+	Status string
+
+	Zebra struct {
+		Zone     string `json:"zone"`
+		Age      int    `json:"age"`
+		Name     string `json:"name"`
+		Embedded `json:",inline"`
+	}
+
+	Embedded struct {
+		Breed string `json:"breed"`
+	}
+)
+
+func Test_IterateOrder(t *testing.T) {
+	sources := []reflect.Type{
+		reflect.TypeOf(Order{}),
+		reflect.TypeOf(Status("")),
+		reflect.TypeOf(Line{}),
+		reflect.TypeOf(Customer{}),
+		reflect.TypeOf(Address{}),
+	}
+
+	tests := []struct {
+		Description string
+		Order       parser.Order
+		Expect      []string
+	}{
+		{
+			Description: "registration order",
+			Order:       parser.OrderRegistration,
+			Expect:      []string{"Order", "Status", "Line", "Customer", "Address"},
+		},
+		{
+			Description: "alphabetical order",
+			Order:       parser.OrderAlphabetical,
+			Expect:      []string{"Address", "Customer", "Line", "Order", "Status"},
+		},
+		{
+			Description: "topological order",
+			Order:       parser.OrderTopological,
+			Expect:      []string{"Address", "Customer", "Line", "Status", "Order"},
+		},
+	}
+
+	for _, test := range tests {
+		// The output must not depend on the order the sources were added in (except for the registration order)
+		for _, reversed := range []bool{false, true} {
+			if reversed && test.Order == parser.OrderRegistration {
+				continue
+			}
+
+			p := parser.New()
+			if err := p.SetConfig(parser.Config{EnableCaching: true, Order: test.Order}); err != nil {
+				t.Fatalf("[%s] unexpected error: %v", test.Description, err)
+			}
+
+			s := slices.Clone(sources)
+			if reversed {
+				slices.Reverse(s)
+			}
+
+			if err := p.AddSources(s...); err != nil {
+				t.Fatalf("[%s] unexpected error: %v", test.Description, err)
+			}
+
+			var names []string
+			if err := p.Iterate(func(item parser.Item) error {
+				names = append(names, item.Name())
+				return nil
+			}); err != nil {
+				t.Fatalf("[%s] unexpected error: %v", test.Description, err)
+			}
+
+			if !slices.Equal(names, test.Expect) {
+				t.Errorf("[%s] (reversed: %v) expected %v, got %v", test.Description, reversed, test.Expect, names)
+			}
+		}
+	}
+}
+
+func Test_SortItems(t *testing.T) {
+	var (
+		a = &parser.Struct{ItemName: "a", PkgPath: "pkg"}
+		b = &parser.Struct{ItemName: "B", PkgPath: "pkg", Fields: []parser.Field{{ItemName: "c", BaseItem: &parser.List{BaseItem: &parser.Struct{ItemName: "C", PkgPath: "pkg"}}}}}
+		c = &parser.Struct{ItemName: "C", PkgPath: "pkg", Fields: []parser.Field{{ItemName: "b", BaseItem: &parser.Struct{ItemName: "B", PkgPath: "pkg"}}}}
+		d = &parser.Struct{ItemName: "a", PkgPath: "other"}
+	)
+
+	tests := []struct {
+		Description string
+		Items       []parser.Item
+		Order       parser.Order
+		Expect      []parser.Item
+	}{
+		{Description: "registration order", Items: []parser.Item{c, a, b}, Order: parser.OrderRegistration, Expect: []parser.Item{c, a, b}},
+		{Description: "case-insensitive alphabetical order", Items: []parser.Item{c, b, a}, Order: parser.OrderAlphabetical, Expect: []parser.Item{a, b, c}},
+		{Description: "same names in different packages", Items: []parser.Item{a, d}, Order: parser.OrderAlphabetical, Expect: []parser.Item{d, a}},
+		{Description: "reference cycle", Items: []parser.Item{c, b}, Order: parser.OrderTopological, Expect: []parser.Item{c, b}},
+		{Description: "reference cycle in another order", Items: []parser.Item{b, c}, Order: parser.OrderTopological, Expect: []parser.Item{c, b}},
+	}
+
+	for _, test := range tests {
+		if got := parser.SortItems(test.Items, test.Order); !slices.Equal(got, test.Expect) {
+			t.Errorf("[%s] expected %v, got %v", test.Description, names(test.Expect), names(got))
+		}
+	}
+}
+
+func Test_SortFields(t *testing.T) {
+	p := parser.New()
+	if err := p.SetConfig(parser.Config{FlattenEmbeddedTypes: true, SortFields: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	item, err := p.Parse(reflect.TypeOf(Zebra{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var fields []string
+	for _, field := range item.(*parser.Struct).Fields {
+		fields = append(fields, field.ItemName)
+	}
+
+	if expect := []string{"age", "breed", "name", "zone"}; !slices.Equal(fields, expect) {
+		t.Errorf("expected fields %v, got %v", expect, fields)
+	}
+}
+
+func names(items []parser.Item) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, parser.QualifiedName(item))
+	}
+
+	return names
+}
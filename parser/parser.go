@@ -2,9 +2,16 @@ package parser
 
 import (
 	"database/sql"
-	"encoding/base64"
+	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.trulyao.dev/mirror/v2/extractor"
@@ -20,11 +27,20 @@ type (
 	CacheValue struct {
 		Options Options
 		Item    *Item
+
+		// structs are the struct types that can be reached from the cached type (including itself), see `reachableStructs`
+		structs map[reflect.Type]struct{}
 	}
 
 	Config struct {
 		EnableCaching        bool
 		FlattenEmbeddedTypes bool
+
+		// Order is the order sources are iterated in (see `Iterate`)
+		Order Order
+
+		// SortFields sorts the fields of structs alphabetically (by the names they are generated with) instead of keeping the order they are declared in
+		SortFields bool
 	}
 
 	CustomType struct {
@@ -32,23 +48,73 @@ type (
 		Item Item
 	}
 
+	// VariantSource is used to register a concrete implementation of an interface as a variant of a discriminated union
+	VariantSource struct {
+		// Tag is the value of the discriminator field for this variant (e.g. "created")
+		Tag string
+
+		// Source is the concrete type implementing the interface (pointers are dereferenced)
+		Source reflect.Type
+	}
+
+	unionDefinition struct {
+		discriminator string
+		variants      []VariantSource
+	}
+
+	// cacheKey uniquely identifies a parsed item in the cache, the type itself is used (instead of the name) so that unnamed types like `[]string` and `map[string]int` do not collide
+	cacheKey struct {
+		source  reflect.Type
+		options Options
+	}
+
+	// parseState holds the state of a single top-level parse call and is never shared between goroutines
+	parseState struct {
+		// visiting maps the types currently being parsed to their depth in the stack, this is used to detect recursive types
+		visiting map[reflect.Type]int
+
+		// shallowestRef is the depth of the shallowest type referenced by a recursive placeholder in the current branch
+		// Items containing placeholders to types above them in the stack are incomplete on their own and must not be cached
+		shallowestRef int
+	}
+
 	OnParseItemFunc func(sourceName string, target Item) error
 
 	OnParseFieldFunc func(parentType *reflect.Type, originalField *reflect.StructField, field *Field) error
 
+	// Parser is safe for concurrent use, however, hooks may be called concurrently and must be safe for concurrent use themselves when the parser is shared between goroutines (e.g. with `ParseAll`)
 	Parser struct {
+		mu sync.RWMutex
+
 		// Sources to parse
 		sources []reflect.Type
 
 		// Cache of parsed items
-		cache map[string]CacheValue
+		cache map[cacheKey]CacheValue
 
 		// Map of custom types with items to be overridden with when encountered
 		customTypes map[string]Item
 
+		// Map of interfaces to their registered implementations (parsed as discriminated unions)
+		unions map[reflect.Type]unionDefinition
+
+		// Map of struct types to the values their fields' defaults are derived from
+		defaults map[reflect.Type]reflect.Value
+
+		// Map of function types to the names of their parameters
+		paramNames map[reflect.Type][]string
+
+		// Map of enum types to their (JSON-encoded) values
+		enums map[reflect.Type][]string
+
+		// Struct tag extractors, run in order with later extractors taking precedence
+		extractors []extractor.Extractor
+
 		// Configuration
 		enableCaching        bool
 		flattenEmbeddedTypes bool
+		order                Order
+		sortFields           bool
 
 		// Hooks
 		onParseItemFn  OnParseItemFunc
@@ -59,8 +125,13 @@ type (
 // New creates a new parser
 func New() *Parser {
 	return &Parser{
-		cache:                make(map[string]CacheValue),
+		cache:                make(map[cacheKey]CacheValue),
 		customTypes:          make(map[string]Item),
+		unions:               make(map[reflect.Type]unionDefinition),
+		defaults:             make(map[reflect.Type]reflect.Value),
+		paramNames:           make(map[reflect.Type][]string),
+		enums:                make(map[reflect.Type][]string),
+		extractors:           extractor.Defaults(),
 		sources:              []reflect.Type{},
 		enableCaching:        true,
 		flattenEmbeddedTypes: false,
@@ -69,21 +140,34 @@ func New() *Parser {
 
 // Reset the parser to its initial state
 func (p *Parser) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.sources = make([]reflect.Type, 0)
-	p.cache = make(map[string]CacheValue)
+	p.cache = make(map[cacheKey]CacheValue)
 }
 
 // Set the parser's configuration
 func (p *Parser) SetConfig(config Config) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.enableCaching = config.EnableCaching
 	p.flattenEmbeddedTypes = config.FlattenEmbeddedTypes
+	p.order = config.Order
+
+	// Cached structs have been parsed with the previous field order
+	if p.sortFields != config.SortFields {
+		p.sortFields = config.SortFields
+		p.cache = make(map[cacheKey]CacheValue)
+	}
 
 	return nil
 }
 
 // Lookup a source by name, returns the source and a boolean indicating if the source was found
 func (p *Parser) LookupByName(name string) (Item, bool) {
-	for _, source := range p.sources {
+	for _, source := range p.Sources() {
 		if source.Name() == name {
 			item, err := p.ParseWithOpts(source)
 			if err != nil {
@@ -98,27 +182,96 @@ func (p *Parser) LookupByName(name string) (Item, bool) {
 }
 
 // Get the sources to parse
+// The returned slice is a copy and can be safely modified
 func (p *Parser) Sources() []reflect.Type {
-	return p.sources
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	sources := make([]reflect.Type, len(p.sources))
+	copy(sources, p.sources)
+
+	return sources
 }
 
 // Set the sources to parse
 func (p *Parser) SetSources(sources []reflect.Type) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.sources = sources
 }
 
 // Enable or disable flattening of embedded structs
 func (p *Parser) SetFlattenEmbeddedTypes(flatten bool) *Parser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.flattenEmbeddedTypes = flatten
 	return p
 }
 
 // Enable or disable caching
 func (p *Parser) SetEnableCaching(enable bool) *Parser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.enableCaching = enable
 	return p
 }
 
+// Get the struct tag extractors used by the parser, in order of precedence (lowest first)
+func (p *Parser) Extractors() []extractor.Extractor {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Clone(p.extractors)
+}
+
+// Set the struct tag extractors used to parse field meta information, this replaces the existing extractors (including the defaults)
+// Extractors are run in the order provided, each one receiving the meta produced by the previous one, so later extractors take precedence over earlier ones
+//
+// For example, to use the `yaml` tag instead of the `json` tag while still allowing the `mirror` tag to override both:
+//
+//	p.SetExtractors(extractor.YAML(), extractor.Mirror())
+func (p *Parser) SetExtractors(extractors ...extractor.Extractor) error {
+	for i, e := range extractors {
+		if e == nil {
+			return fmt.Errorf("extractor at index %d is nil", i)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.extractors = slices.Clone(extractors)
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
+// Add a struct tag extractor to the parser
+// The extractor takes precedence over all existing extractors except the `validate` and `mirror` tag extractors (if present) which are always kept last so that `required` rules and the `mirror` tag can override every other tag, use `SetExtractors` for full control over the order
+func (p *Parser) AddExtractor(e extractor.Extractor) error {
+	if e == nil {
+		return fmt.Errorf("extractor cannot be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := slices.IndexFunc(p.extractors, func(e extractor.Extractor) bool {
+		return e.Tag() == "validate" || e.Tag() == "mirror"
+	})
+	if idx == -1 {
+		idx = len(p.extractors)
+	}
+
+	p.extractors = slices.Insert(slices.Clone(p.extractors), idx, e)
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
 // Add a custom type to the parser
 // Takes the name of the type and the item to override it with when encountered
 func (p *Parser) AddCustomType(name string, item Item) error {
@@ -130,7 +283,14 @@ func (p *Parser) AddCustomType(name string, item Item) error {
 		return fmt.Errorf("item cannot be nil")
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.customTypes[name] = item
+
+	// Previously parsed items may contain the type that is now being overridden
+	p.cache = make(map[cacheKey]CacheValue)
+
 	return nil
 }
 
@@ -145,16 +305,217 @@ func (p *Parser) AddCustomTypes(customTypes []CustomType) error {
 	return nil
 }
 
+// VariantOf creates a union variant from a value of the concrete type and its discriminator value
+func VariantOf(tag string, value any) VariantSource {
+	return VariantSource{Tag: tag, Source: reflect.TypeOf(value)}
+}
+
+// Register the known implementations of an interface so that the interface is parsed as a discriminated union instead of `any`
+// Takes the interface type, the name of the discriminator field and the variants; each variant's tag is the value of the discriminator field for that variant
+func (p *Parser) AddUnion(iface reflect.Type, discriminator string, variants ...VariantSource) error {
+	if iface == nil {
+		return fmt.Errorf("interface type cannot be nil")
+	}
+
+	// Allow passing in a pointer to the interface (e.g. `reflect.TypeOf((*Event)(nil))`)
+	if iface.Kind() == reflect.Pointer {
+		iface = iface.Elem()
+	}
+
+	if iface.Kind() != reflect.Interface {
+		return fmt.Errorf("expected an interface type, got `%s`", iface.Kind())
+	}
+
+	if discriminator == "" {
+		return fmt.Errorf("discriminator cannot be empty")
+	}
+
+	if len(variants) == 0 {
+		return fmt.Errorf("at least one variant must be provided for union `%s`", iface.Name())
+	}
+
+	// The variants are normalized and stored, so the caller's slice is copied to leave it untouched
+	variants = slices.Clone(variants)
+
+	tags := make(map[string]bool, len(variants))
+	for i, variant := range variants {
+		if variant.Tag == "" {
+			return fmt.Errorf("variant %d of union `%s` has an empty tag", i, iface.Name())
+		}
+
+		if tags[variant.Tag] {
+			return fmt.Errorf("duplicate tag `%s` in union `%s`", variant.Tag, iface.Name())
+		}
+		tags[variant.Tag] = true
+
+		if variant.Source == nil {
+			return fmt.Errorf("variant `%s` of union `%s` has no source", variant.Tag, iface.Name())
+		}
+
+		source := variant.Source
+		if source.Kind() == reflect.Pointer {
+			source = source.Elem()
+		}
+
+		if source.Kind() != reflect.Struct {
+			return fmt.Errorf("variant `%s` of union `%s` must be a struct, got `%s`", variant.Tag, iface.Name(), source.Kind())
+		}
+
+		if !variant.Source.Implements(iface) && !reflect.PointerTo(source).Implements(iface) {
+			return fmt.Errorf("`%s` does not implement `%s`", source.Name(), iface.Name())
+		}
+
+		variants[i].Source = source
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.unions[iface] = unionDefinition{discriminator: discriminator, variants: variants}
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
+// Register the names of the parameters of a function type (e.g. `p.SetParamNames(reflect.TypeOf(GetUser(nil)), "ctx", "request")`), Go does not keep the names of parameters at runtime
+// Unnamed function types with the same signature are the same type, so they share their parameter names
+func (p *Parser) SetParamNames(source reflect.Type, names ...string) error {
+	if source == nil {
+		return fmt.Errorf("function type cannot be nil")
+	}
+
+	if source.Kind() != reflect.Func {
+		return fmt.Errorf("expected a function type, got `%s`", source.Kind())
+	}
+
+	if len(names) != source.NumIn() {
+		return fmt.Errorf("expected %d parameter names for `%s`, got %d", source.NumIn(), source, len(names))
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !meta.FieldNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid parameter name `%s` for `%s`", name, source)
+		}
+
+		if seen[name] {
+			return fmt.Errorf("duplicate parameter name `%s` for `%s`", name, source)
+		}
+		seen[name] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.paramNames[source] = slices.Clone(names)
+
+	// Previously parsed items may contain the function without its parameter names
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
+// Add an enum source to the parser along with all of its values (e.g. `p.AddEnum(StatusActive, StatusInactive)`), Go does not keep track of the constants of a type at runtime
+// Enums must be string or integer types, all the values must be of the same type and they are generated in the order they are passed in
+func (p *Parser) AddEnum(values ...any) error {
+	if len(values) == 0 {
+		return fmt.Errorf("enum must have at least one value")
+	}
+
+	source := reflect.TypeOf(values[0])
+	if source == nil {
+		return fmt.Errorf("enum value cannot be nil")
+	}
+
+	switch source.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return fmt.Errorf("expected a string or integer type for enum `%s`, got `%s`", source, source.Kind())
+	}
+
+	// Registering a built-in type (e.g. `string`) would turn every value of that type into an enum
+	if !isNamed(source) {
+		return fmt.Errorf("enum `%s` must be a named type (e.g. `type Status string`)", source)
+	}
+
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		if reflect.TypeOf(value) != source {
+			return fmt.Errorf("expected values of enum `%s` to be of the same type, got `%T`", source, value)
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value `%v` of enum `%s`: %w", value, source, err)
+		}
+
+		if slices.Contains(encoded, string(data)) {
+			return fmt.Errorf("duplicate value `%s` for enum `%s`", data, source)
+		}
+
+		encoded = append(encoded, string(data))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sources = append(p.sources, source)
+	p.enums[source] = encoded
+
+	// Previously parsed items may contain the enum without its values
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
 // Add a source to the parser
 func (p *Parser) AddSource(source reflect.Type) error {
 	if source == nil {
 		return fmt.Errorf("source cannot be nil")
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.sources = append(p.sources, source)
 	return nil
 }
 
+// Add a struct source to the parser and use the values of its fields as their default values (e.g. `p.AddSourceWithDefaults(NewConfig())`)
+// Default values set with the `default` attribute of the `mirror` tag take precedence over the values of the source
+func (p *Parser) AddSourceWithDefaults(value any) error {
+	source := reflect.TypeOf(value)
+	if source == nil {
+		return fmt.Errorf("source cannot be nil")
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return fmt.Errorf("source with defaults cannot be a nil pointer")
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct for source with defaults, got `%s`", v.Kind())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sources = append(p.sources, source)
+	p.defaults[v.Type()] = v
+
+	// Previously parsed items may contain the struct without its defaults
+	p.cache = make(map[cacheKey]CacheValue)
+
+	return nil
+}
+
 // Add multiple sources to the parser
 func (p *Parser) AddSources(sources ...reflect.Type) error {
 	for _, source := range sources {
@@ -168,36 +529,55 @@ func (p *Parser) AddSources(sources ...reflect.Type) error {
 
 // Count the number of sources left to parse
 func (p *Parser) Count() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	return len(p.sources)
 }
 
 // Check if there are any sources left to parse
 func (p *Parser) Done() bool {
-	return len(p.sources) == 0
+	return p.Count() == 0
 }
 
 // Parse the next source in the list of sources, this function consumes the source and removes it from the list
 // Call `Done` to check if there are any sources left
 func (p *Parser) Next() (Item, error) {
+	p.mu.Lock()
 	if len(p.sources) == 0 {
+		p.mu.Unlock()
 		return nil, fmt.Errorf("no sources to parse")
 	}
 
 	source := p.sources[0]
 	p.sources = p.sources[1:]
+	p.mu.Unlock()
 
 	return p.ParseWithOpts(source)
 }
 
-// Iterate over all sources and call the function `f` on each source
+// Iterate over all sources and call the function `f` on each source, in the configured order (see `Config.Order`)
 // Unlike `Next`, this function does not consume the sources and can be called multiple times
+//
+// NOTE: the sources are snapshotted and parsed before iterating, so it is safe to call other parser methods (like `LookupByName`) from within `f`
 func (p *Parser) Iterate(f func(Item) error) error {
-	for _, source := range p.sources {
+	sources := p.Sources()
+
+	items := make([]Item, 0, len(sources))
+	for _, source := range sources {
 		item, err := p.ParseWithOpts(source)
 		if err != nil {
 			return err
 		}
 
+		items = append(items, item)
+	}
+
+	p.mu.RLock()
+	order := p.order
+	p.mu.RUnlock()
+
+	for _, item := range SortItems(items, order) {
 		if err := f(item); err != nil {
 			return err
 		}
@@ -211,6 +591,9 @@ func (p *Parser) Iterate(f func(Item) error) error {
 // If an error is returned, the item will not be cached or returned, and the error will be returned
 func (p *Parser) OnParseItem(fn OnParseItemFunc) {
 	if fn != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
 		p.onParseItemFn = fn
 	}
 }
@@ -220,6 +603,9 @@ func (p *Parser) OnParseItem(fn OnParseItemFunc) {
 // If an error is returned, the field will not be attached to the original item and the error will be returned
 func (p *Parser) OnParseField(fn OnParseFieldFunc) {
 	if fn != nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
 		p.onParseFieldFn = fn
 	}
 }
@@ -230,15 +616,97 @@ func (p *Parser) ParseN(n int) (Item, error) {
 		return nil, fmt.Errorf("n must be a positive integer")
 	}
 
-	if len(p.sources) < n {
+	p.mu.RLock()
+	if len(p.sources) <= n {
+		p.mu.RUnlock()
 		return nil, fmt.Errorf("not enough sources to parse")
 	}
 
 	source := p.sources[n]
+	p.mu.RUnlock()
 
 	return p.ParseWithOpts(source)
 }
 
+// ParseAll parses all sources concurrently using a bounded pool of workers and returns the parsed items in the same order as the sources
+// If `workers` is less than 1, the number of available CPUs is used. All workers share the parser's cache
+//
+// Parsing stops at the first error, the sources before the failing one are still parsed so that the error of the earliest failing source (in source order) is returned
+func (p *Parser) ParseAll(workers int) ([]Item, error) {
+	sources := p.Sources()
+	if len(sources) == 0 {
+		return []Item{}, nil
+	}
+
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+
+	var (
+		items = make([]Item, len(sources))
+		errs  = make([]error, len(sources))
+		jobs  = make(chan int)
+		wg    sync.WaitGroup
+
+		// firstFailure is the index of the earliest source that failed so far (or the number of sources if none has failed)
+		firstFailure atomic.Int64
+	)
+	firstFailure.Store(int64(len(sources)))
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobs {
+				// Sources after a failed one are skipped, the ones before it may still fail with an earlier error
+				if int64(idx) > firstFailure.Load() {
+					continue
+				}
+
+				item, err := p.ParseWithOpts(sources[idx])
+				if err != nil {
+					errs[idx] = err
+					for {
+						current := firstFailure.Load()
+						if int64(idx) >= current || firstFailure.CompareAndSwap(current, int64(idx)) {
+							break
+						}
+					}
+					continue
+				}
+
+				items[idx] = item
+			}
+		}()
+	}
+
+	// Sources are sent in order, so every source before the earliest failure is always parsed
+	for idx := range sources {
+		if int64(idx) > firstFailure.Load() {
+			break
+		}
+
+		jobs <- idx
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for idx, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse source `%s`: %w", sources[idx].Name(), err)
+		}
+	}
+
+	return items, nil
+}
+
 // The public (interface) method to parse a type
 func (p *Parser) Parse(source reflect.Type) (Item, error) {
 	return p.ParseWithOpts(source)
@@ -273,27 +741,6 @@ func (p *Parser) Parse(source reflect.Type) (Item, error) {
 //
 // ```
 func (p *Parser) ParseWithOpts(source reflect.Type, opts ...Options) (Item, error) {
-	var cacheKey string
-
-	if p.enableCaching {
-		cacheKey = base64.StdEncoding.EncodeToString(
-			[]byte(source.PkgPath() + ":" + source.Name()),
-		)
-
-		if value, ok := p.cache[cacheKey]; ok && reflect.DeepEqual(value.Options, opts) {
-			return *value.Item, nil
-		}
-	}
-
-	// If the source is a custom type, return the custom type and cache that too
-	if customType, ok := p.customTypes[source.Name()]; ok {
-		if p.enableCaching {
-			p.cache[cacheKey] = CacheValue{Options: Options{}, Item: &customType}
-		}
-
-		return customType, nil
-	}
-
 	opt := Options{}
 
 	if len(opts) > 0 {
@@ -307,11 +754,55 @@ func (p *Parser) ParseWithOpts(source reflect.Type, opts ...Options) (Item, erro
 		opt = opts[0]
 	}
 
+	state := &parseState{
+		visiting:      make(map[reflect.Type]int),
+		shallowestRef: math.MaxInt,
+	}
+
+	return p.parse(state, source, opt)
+}
+
+// parse is the recursive implementation of `ParseWithOpts`, the state is scoped to a single top-level call so that concurrent calls never share it
+func (p *Parser) parse(state *parseState, source reflect.Type, opt Options) (Item, error) {
+	key := cacheKey{source: source, options: opt}
+
+	p.mu.RLock()
+	enableCaching := p.enableCaching
+	customType, isCustomType := p.customTypes[source.Name()]
+	cached, isCached := p.cache[key]
+	enumValues, isEnum := p.enums[source]
+	onParseItemFn := p.onParseItemFn
+	p.mu.RUnlock()
+
+	// Cached items are parsed from the top (i.e. with their own recursive fields as placeholders), so they can only be used when none of the structs in them are being parsed already
+	// Otherwise the shape of recursive items would depend on which source happened to be parsed (and cached) first
+	if enableCaching && isCached && !state.isVisitingAny(cached.structs) {
+		return *cached.Item, nil
+	}
+
+	// If the source is a custom type, return the custom type and cache that too
+	if isCustomType {
+		p.store(key, customType, nil)
+		return customType, nil
+	}
+
 	nullable := false
 	if opt.OverrideNullable != nullable {
 		nullable = opt.OverrideNullable
 	}
 
+	// Recursive types (e.g. `type Node struct { Children []Node }`) are parsed as a reference to the struct currently being parsed (a struct with the name but no fields) to avoid infinite recursion
+	if depth, ok := state.visiting[source]; ok {
+		state.shallowestRef = min(state.shallowestRef, depth)
+		return &Struct{ItemName: source.Name(), PkgPath: source.PkgPath(), Nullable: nullable}, nil
+	}
+
+	depth := len(state.visiting)
+	if source.Kind() == reflect.Struct {
+		state.visiting[source] = depth
+		defer delete(state.visiting, source)
+	}
+
 	var (
 		item Item
 		err  error
@@ -324,45 +815,56 @@ func (p *Parser) ParseWithOpts(source reflect.Type, opts ...Options) (Item, erro
 		reflect.Int16,
 		reflect.Int32,
 		reflect.Int64,
-		reflect.Int,
+		reflect.Int:
+		item = &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeInteger, Nullable: nullable, BitSize: source.Bits(), Named: isNamed(source)}
+
+	case
 		reflect.Uint8,
 		reflect.Uint16,
 		reflect.Uint32,
 		reflect.Uint64,
 		reflect.Uint:
-		item = &Scalar{source.Name(), TypeInteger, nullable}
+		item = &Scalar{
+			ItemName: source.Name(),
+			PkgPath:  source.PkgPath(),
+			ItemType: TypeInteger,
+			Nullable: nullable,
+			BitSize:  source.Bits(),
+			Unsigned: true,
+			Named:    isNamed(source),
+		}
 
 	case reflect.Float32, reflect.Float64:
-		item = &Scalar{source.Name(), TypeFloat, nullable}
+		item = &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeFloat, Nullable: nullable, BitSize: source.Bits(), Named: isNamed(source)}
 
 	case reflect.String:
-		item = &Scalar{source.Name(), TypeString, nullable}
+		item = &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeString, Nullable: nullable, Named: isNamed(source)}
 
 	case reflect.Bool:
-		item = &Scalar{source.Name(), TypeBoolean, nullable}
+		item = &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeBoolean, Nullable: nullable, Named: isNamed(source)}
 
 	case reflect.Map:
-		item, err = p.parseMap(source, nullable)
+		item, err = p.parseMap(state, source, nullable)
 
 	case reflect.Struct:
 		// Attempt to parse exempted structs like `sql.NullX` types
 		item, err = p.parseExemptedStructs(source, nullable)
 		if err != nil {
 			// If it is not an exempted struct, parse it as a regular struct
-			item, err = p.parseStruct(source, nullable)
+			item, err = p.parseStruct(state, source, nullable)
 		}
 
 	case reflect.Array, reflect.Slice:
-		item, err = p.parseList(source, nullable)
+		item, err = p.parseList(state, source, nullable)
 
 	case reflect.Func:
-		item, err = p.parseFunc(source, nullable)
+		item, err = p.parseFunc(state, source, nullable)
 
 	case reflect.Pointer:
-		item, err = p.ParseWithOpts(source.Elem(), Options{OverrideNullable: true})
+		item, err = p.parse(state, source.Elem(), Options{OverrideNullable: true})
 
 	case reflect.Interface:
-		item, err = p.parseInterface(source, nullable)
+		item, err = p.parseInterface(state, source, nullable)
 
 	default:
 		return nil, notImplementedFor(source, "ParseWithOpts")
@@ -372,21 +874,103 @@ func (p *Parser) ParseWithOpts(source reflect.Type, opts ...Options) (Item, erro
 		return nil, err
 	}
 
-	// Add item to cache if caching is enabled
-	if p.enableCaching {
-		p.cache[cacheKey] = CacheValue{Options: opt, Item: &item}
+	if scalar, ok := item.(*Scalar); ok && isEnum {
+		scalar.Enum = slices.Clone(enumValues)
 	}
 
 	// Run the `OnParseItem` hook if present
-	if p.onParseItemFn != nil {
-		if err := p.onParseItemFn(source.Name(), item); err != nil {
+	if onParseItemFn != nil {
+		if err := onParseItemFn(source.Name(), item); err != nil {
 			return nil, err
 		}
 	}
 
+	// Only cache the item if it does not contain placeholders to types above it in the stack, otherwise it is incomplete when used on its own
+	if state.shallowestRef >= depth {
+		state.shallowestRef = math.MaxInt
+		if enableCaching {
+			p.store(key, item, p.reachableStructs(source))
+		}
+	}
+
 	return item, nil
 }
 
+// store adds an item to the cache if caching is enabled
+func (p *Parser) store(key cacheKey, item Item, structs map[reflect.Type]struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.enableCaching {
+		p.cache[key] = CacheValue{Options: key.options, Item: &item, structs: structs}
+	}
+}
+
+// isVisitingAny checks if any of the types are currently being parsed
+func (s *parseState) isVisitingAny(types map[reflect.Type]struct{}) bool {
+	if len(s.visiting) == 0 {
+		return false
+	}
+
+	for t := range types {
+		if _, ok := s.visiting[t]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reachableStructs returns the struct types that can be reached from a type (including the type itself), through fields, elements, pointers, function signatures and union variants
+func (p *Parser) reachableStructs(source reflect.Type) map[reflect.Type]struct{} {
+	var (
+		structs = make(map[reflect.Type]struct{})
+		seen    = make(map[reflect.Type]struct{})
+		walk    func(t reflect.Type)
+	)
+
+	walk = func(t reflect.Type) {
+		if _, ok := seen[t]; ok {
+			return
+		}
+		seen[t] = struct{}{}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			structs[t] = struct{}{}
+			for i := 0; i < t.NumField(); i++ {
+				walk(t.Field(i).Type)
+			}
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			walk(t.Elem())
+		case reflect.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case reflect.Func:
+			for i := 0; i < t.NumIn(); i++ {
+				walk(t.In(i))
+			}
+
+			for i := 0; i < t.NumOut(); i++ {
+				walk(t.Out(i))
+			}
+		case reflect.Interface:
+			p.mu.RLock()
+			definition, isUnion := p.unions[t]
+			p.mu.RUnlock()
+
+			if isUnion {
+				for _, variant := range definition.variants {
+					walk(variant.Source)
+				}
+			}
+		}
+	}
+
+	walk(source)
+	return structs
+}
+
 // Parse a struct field and extract the meta information
 func (p *Parser) parseField(fieldName string, field reflect.StructField) (meta.Meta, error) {
 	rootMeta := meta.Meta{}
@@ -394,61 +978,67 @@ func (p *Parser) parseField(fieldName string, field reflect.StructField) (meta.M
 	rootMeta.OriginalName = helper.WithDefaultString(fieldName, field.Name)
 	rootMeta.Name = helper.WithDefaultString(fieldName, field.Name)
 
-	// Parse the JSON struct tag first
-	jsonMeta, err := extractor.ExtractJSONMeta(field, &rootMeta)
-	if err != nil {
-		return meta.Meta{}, err
-	}
+	p.mu.RLock()
+	extractors := p.extractors
+	p.mu.RUnlock()
 
-	// Parse the custom `mirror` struct tags to override the JSON struct tag if present
-	mirrorMeta, err := extractor.ExtractMirrorMeta(field, jsonMeta)
-	if err != nil {
-		return meta.Meta{}, err
+	// Run the extractors in order (by default; the JSON struct tag first and then the custom `mirror` struct tag to override it if present)
+	fieldMeta := &rootMeta
+	for _, e := range extractors {
+		var err error
+
+		if fieldMeta, err = e.Extract(field, fieldMeta); err != nil {
+			return meta.Meta{}, fmt.Errorf("failed to extract `%s` tag: %w", e.Tag(), err)
+		}
 	}
 
-	return *mirrorMeta, nil
+	return *fieldMeta, nil
 }
 
 // Parse exempted structs like `sql.NullX` types and other built-in types
 func (p *Parser) parseExemptedStructs(source reflect.Type, nullable bool) (Item, error) {
 	switch {
 	case source == reflect.TypeOf(time.Time{}):
-		return &Scalar{source.Name(), TypeTimestamp, nullable}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeTimestamp, Nullable: nullable}, nil
 
 	case source == reflect.TypeOf(time.Duration(0)):
-		return &Scalar{source.Name(), TypeInteger, nullable}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeInteger, Nullable: nullable, BitSize: 64}, nil
 
 	case source == reflect.TypeOf([]byte{}):
-		return &Scalar{source.Name(), TypeString, nullable}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeString, Nullable: nullable}, nil
 
 	case source == reflect.TypeOf([]any{}):
 		return &List{
 			ItemName: source.Name(),
-			BaseItem: &Scalar{"any", TypeAny, nullable},
+			PkgPath:  source.PkgPath(),
+			BaseItem: &Scalar{ItemName: "any", ItemType: TypeAny, Nullable: nullable},
 			Nullable: nullable,
 		}, nil
 
 	// SQL types
 	case source == reflect.TypeOf(sql.NullBool{}):
-		return &Scalar{source.Name(), TypeBoolean, true}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeBoolean, Nullable: true}, nil
 
 	case source == reflect.TypeOf(sql.NullFloat64{}):
-		return &Scalar{source.Name(), TypeFloat, true}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeFloat, Nullable: true, BitSize: 64}, nil
 
-	case
-		source == reflect.TypeOf(sql.NullInt64{}),
-		source == reflect.TypeOf(sql.NullInt32{}),
-		source == reflect.TypeOf(sql.NullInt16{}):
-		return &Scalar{source.Name(), TypeInteger, true}, nil
+	case source == reflect.TypeOf(sql.NullInt64{}):
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeInteger, Nullable: true, BitSize: 64}, nil
+
+	case source == reflect.TypeOf(sql.NullInt32{}):
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeInteger, Nullable: true, BitSize: 32}, nil
+
+	case source == reflect.TypeOf(sql.NullInt16{}):
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeInteger, Nullable: true, BitSize: 16}, nil
 
 	case source == reflect.TypeOf(sql.NullString{}):
-		return &Scalar{source.Name(), TypeString, true}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeString, Nullable: true}, nil
 
 	case source == reflect.TypeOf(sql.NullTime{}):
-		return &Scalar{source.Name(), TypeTimestamp, true}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeTimestamp, Nullable: true}, nil
 
 	case source == reflect.TypeOf(sql.NullByte{}):
-		return &Scalar{source.Name(), TypeByte, true}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeByte, Nullable: true, BitSize: 8, Unsigned: true}, nil
 
 	default:
 		return nil, notImplementedFor(source, "parseExemptedStructs")
@@ -456,14 +1046,27 @@ func (p *Parser) parseExemptedStructs(source reflect.Type, nullable bool) (Item,
 }
 
 // Parse a struct type
-func (p *Parser) parseStruct(source reflect.Type, nullable bool) (*Struct, error) {
+func (p *Parser) parseStruct(state *parseState, source reflect.Type, nullable bool) (*Struct, error) {
 	fields := make([]Field, 0)
 
+	p.mu.RLock()
+	flattenEmbeddedTypes := p.flattenEmbeddedTypes
+	sortFieldsByName := p.sortFields
+	onParseFieldFn := p.onParseFieldFn
+	defaults, hasDefaults := p.defaults[source]
+	p.mu.RUnlock()
+
 	withOnParseFieldHook := func(field *Field, sourceField *reflect.StructField) error {
-		if p.onParseFieldFn != nil {
-			if err := p.onParseFieldFn(&source, sourceField, field); err != nil {
+		if onParseFieldFn != nil {
+			name := field.Meta.Name
+			if err := onParseFieldFn(&source, sourceField, field); err != nil {
 				return fmt.Errorf("failed to run `OnParseField` hook: %s", err.Error())
 			}
+
+			// Names set by hooks are just as explicit as the ones set with struct tags
+			if field.Meta.Name != name {
+				field.Meta.ExplicitName = true
+			}
 		}
 
 		return nil
@@ -478,16 +1081,26 @@ func (p *Parser) parseStruct(source reflect.Type, nullable bool) (*Struct, error
 		}
 
 		// If it is embedded, parse it as part of the original struct (flatten it)
-		if p.flattenEmbeddedTypes && sourceField.Anonymous &&
+		if flattenEmbeddedTypes && sourceField.Anonymous &&
 			sourceField.Type.Kind() == reflect.Struct {
-			item, err := p.ParseWithOpts(sourceField.Type)
+			item, err := p.parse(state, sourceField.Type, Options{})
 			if err != nil {
 				return &Struct{}, err
 			}
 
 			switch nestedItem := item.(type) {
 			case *Struct:
-				fields = append(fields, nestedItem.Fields...)
+				// Keep track of where the fields came from so that generators can reference the embedded type instead of copying its fields
+				for _, field := range nestedItem.Fields {
+					field.EmbeddedFrom = nestedItem
+					if hasDefaults && field.Meta.Default == "" {
+						if field.Meta.Default, err = encodeDefault(defaults.Field(i).FieldByName(field.Meta.OriginalName)); err != nil {
+							return &Struct{}, fmt.Errorf("failed to encode default value of field `%s`: %w", field.Meta.OriginalName, err)
+						}
+					}
+
+					fields = append(fields, field)
+				}
 
 			default:
 				// Account for the case where the embedded type is not a struct
@@ -513,11 +1126,23 @@ func (p *Parser) parseStruct(source reflect.Type, nullable bool) (*Struct, error
 			return &Struct{}, err
 		}
 
-		item, err := p.ParseWithOpts(sourceField.Type)
+		item, err := p.parse(state, sourceField.Type, Options{})
 		if err != nil {
 			return &Struct{}, err
 		}
 
+		if hasDefaults && meta.Default == "" {
+			if meta.Default, err = encodeDefault(defaults.Field(i)); err != nil {
+				return &Struct{}, fmt.Errorf("failed to encode default value of field `%s`: %w", sourceField.Name, err)
+			}
+		}
+
+		// Fixed-length arrays are always encoded with exactly `Length` elements, unless the user has said otherwise
+		if list, ok := item.(*List); ok && list.IsArray() && meta.Constraints.MinLength == nil && meta.Constraints.MaxLength == nil {
+			minLength, maxLength := list.Length, list.Length
+			meta.Constraints.MinLength, meta.Constraints.MaxLength = &minLength, &maxLength
+		}
+
 		field := Field{ItemName: meta.Name, BaseItem: item, Meta: meta}
 		if err := withOnParseFieldHook(&field, &sourceField); err != nil {
 			return &Struct{}, err
@@ -526,27 +1151,67 @@ func (p *Parser) parseStruct(source reflect.Type, nullable bool) (*Struct, error
 		fields = append(fields, field)
 	}
 
-	return &Struct{ItemName: source.Name(), Fields: fields, Nullable: nullable}, nil
+	if sortFieldsByName {
+		sortFields(fields)
+	}
+
+	return &Struct{ItemName: source.Name(), PkgPath: source.PkgPath(), Fields: fields, Nullable: nullable}, nil
+}
+
+// encodeDefault encodes the value of a field as JSON to be used as its default value
+func encodeDefault(value reflect.Value) (string, error) {
+	if !value.IsValid() || !value.CanInterface() {
+		return "", nil
+	}
+
+	// Values that cannot be represented in JSON (e.g. functions and channels) have no default value
+	encoded, err := json.Marshal(value.Interface())
+	var unsupportedType *json.UnsupportedTypeError
+	if errors.As(err, &unsupportedType) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
 }
 
 // Parse a map type
-func (p *Parser) parseMap(source reflect.Type, nullable bool) (*Map, error) {
-	keyItem, err := p.ParseWithOpts(source.Key())
+func (p *Parser) parseMap(state *parseState, source reflect.Type, nullable bool) (*Map, error) {
+	keyItem, err := p.parseMapKey(state, source.Key())
 	if err != nil {
 		return &Map{}, err
 	}
 
-	valueItem, err := p.ParseWithOpts(source.Elem())
+	valueItem, err := p.parse(state, source.Elem(), Options{})
 	if err != nil {
 		return &Map{}, err
 	}
 
-	return &Map{source.Name(), keyItem, valueItem, nullable}, nil
+	return &Map{ItemName: source.Name(), PkgPath: source.PkgPath(), Key: keyItem, Value: valueItem, Nullable: nullable}, nil
+}
+
+// textMarshalerType is used to check if map keys are encoded as strings by `encoding/json`
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// parseMapKey parses the key of a map the way `encoding/json` encodes it; string keys are parsed as they are while keys of any other kind implementing `encoding.TextMarshaler` (e.g. `time.Time` or an integer type with a `MarshalText` method) are parsed as strings
+// Other keys (e.g. integers) and custom types are parsed as usual and it is up to the targets to decide whether they are supported
+func (p *Parser) parseMapKey(state *parseState, source reflect.Type) (Item, error) {
+	p.mu.RLock()
+	_, isCustomType := p.customTypes[source.Name()]
+	p.mu.RUnlock()
+
+	// `encoding/json` uses string keys as they are, even if they implement `encoding.TextMarshaler`, but checks for it before falling back to integer keys
+	if source.Kind() != reflect.String && !isCustomType && source.Implements(textMarshalerType) {
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeString}, nil
+	}
+
+	return p.parse(state, source, Options{})
 }
 
 // Parse a list type (slice or array)
-func (p *Parser) parseList(source reflect.Type, nullable bool) (*List, error) {
-	item, err := p.ParseWithOpts(source.Elem())
+func (p *Parser) parseList(state *parseState, source reflect.Type, nullable bool) (*List, error) {
+	item, err := p.parse(state, source.Elem(), Options{})
 	if err != nil {
 		return &List{}, err
 	}
@@ -556,16 +1221,21 @@ func (p *Parser) parseList(source reflect.Type, nullable bool) (*List, error) {
 		length = source.Len()
 	}
 
-	return &List{ItemName: source.Name(), BaseItem: item, Nullable: nullable, Length: length}, nil
+	return &List{ItemName: source.Name(), PkgPath: source.PkgPath(), BaseItem: item, Nullable: nullable, Length: length}, nil
+}
+
+// isNamed checks if a type is declared in a package (e.g. `type UserID string`) as opposed to a built-in type
+func isNamed(source reflect.Type) bool {
+	return source.Name() != "" && source.PkgPath() != ""
 }
 
 // Parse a function type
-func (p *Parser) parseFunc(source reflect.Type, nullable bool) (*Function, error) {
+func (p *Parser) parseFunc(state *parseState, source reflect.Type, nullable bool) (*Function, error) {
 	params := make([]Item, 0)
 	returns := make([]Item, 0)
 
 	for i := 0; i < source.NumIn(); i++ {
-		param, err := p.ParseWithOpts(source.In(i))
+		param, err := p.parse(state, source.In(i), Options{})
 		if err != nil {
 			return &Function{}, err
 		}
@@ -574,7 +1244,7 @@ func (p *Parser) parseFunc(source reflect.Type, nullable bool) (*Function, error
 	}
 
 	for i := 0; i < source.NumOut(); i++ {
-		ret, err := p.ParseWithOpts(source.Out(i))
+		ret, err := p.parse(state, source.Out(i), Options{})
 		if err != nil {
 			return &Function{}, err
 		}
@@ -582,25 +1252,61 @@ func (p *Parser) parseFunc(source reflect.Type, nullable bool) (*Function, error
 		returns = append(returns, ret)
 	}
 
+	p.mu.RLock()
+	paramNames := slices.Clone(p.paramNames[source])
+	p.mu.RUnlock()
+
 	return &Function{
-		ItemName: source.Name(),
-		Params:   params,
-		Returns:  returns,
-		Nullable: nullable,
+		ItemName:   source.Name(),
+		PkgPath:    source.PkgPath(),
+		Params:     params,
+		ParamNames: paramNames,
+		Returns:    returns,
+		Nullable:   nullable,
 	}, nil
 }
 
 // Parse an interface type
 // This accounts for various types like `interface{}`, `error`, `time.Time`, `sql.NullX` types
-func (p *Parser) parseInterface(source reflect.Type, nullable bool) (Item, error) {
+func (p *Parser) parseInterface(state *parseState, source reflect.Type, nullable bool) (Item, error) {
+	p.mu.RLock()
+	definition, isUnion := p.unions[source]
+	p.mu.RUnlock()
+
+	if isUnion {
+		return p.parseUnion(state, source, definition, nullable)
+	}
+
 	switch source.Name() {
 	case "error":
-		return &Scalar{source.Name(), TypeString, nullable}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeString, Nullable: nullable}, nil
 	default:
-		return &Scalar{source.Name(), TypeAny, nullable}, nil
+		return &Scalar{ItemName: source.Name(), PkgPath: source.PkgPath(), ItemType: TypeAny, Nullable: nullable}, nil
 	}
 }
 
+// Parse a registered interface as a discriminated union of its variants
+func (p *Parser) parseUnion(state *parseState, source reflect.Type, definition unionDefinition, nullable bool) (*Union, error) {
+	variants := make([]UnionVariant, 0, len(definition.variants))
+
+	for _, variant := range definition.variants {
+		item, err := p.parse(state, variant.Source, Options{})
+		if err != nil {
+			return &Union{}, fmt.Errorf("failed to parse variant `%s` of union `%s`: %s", variant.Tag, source.Name(), err.Error())
+		}
+
+		variants = append(variants, UnionVariant{Tag: variant.Tag, Item: item})
+	}
+
+	return &Union{
+		ItemName:      source.Name(),
+		PkgPath:       source.PkgPath(),
+		Discriminator: definition.discriminator,
+		Variants:      variants,
+		Nullable:      nullable,
+	}, nil
+}
+
 // Construct an error for unsupported types
 func notImplementedFor(source reflect.Type, op string) error {
 	name := source.Name()
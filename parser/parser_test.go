@@ -1,17 +1,24 @@
 package parser_test
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"go.trulyao.dev/mirror/v2/extractor"
 	"go.trulyao.dev/mirror/v2/extractor/meta"
 	"go.trulyao.dev/mirror/v2/parser"
 )
 
+const testPkgPath = "go.trulyao.dev/mirror/v2/parser_test"
+
 type Test struct {
 	Description string
 	Source      any
@@ -37,7 +44,7 @@ func Test_ParseItem_Opts(t *testing.T) {
 			Description: "parse integer with nullable overridden to true",
 			Opt:         parser.Options{OverrideNullable: true},
 			Source:      *new(Foo),
-			Expected:    &parser.Scalar{"Foo", parser.TypeInteger, true},
+			Expected:    &parser.Scalar{ItemName: "Foo", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: true, BitSize: 64, Named: true},
 		},
 	}
 
@@ -68,6 +75,10 @@ func Test_ParseItem_Scalar(t *testing.T) {
 		Foo32 int32
 		Foo64 int64
 
+		// Unsigned ints
+		UFoo8  uint8
+		UFoo64 uint64
+
 		// Floats
 		Float32 float32
 		Float64 float64
@@ -81,47 +92,73 @@ func Test_ParseItem_Scalar(t *testing.T) {
 		{
 			Description: "parse integer",
 			Source:      *new(Foo),
-			Expected:    &parser.Scalar{"Foo", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Foo", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: false, BitSize: 64, Named: true},
 		},
 		{
 			Description: "parse i8",
 			Source:      *new(Foo8),
-			Expected:    &parser.Scalar{"Foo8", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Foo8", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: false, BitSize: 8, Named: true},
 		},
 		{
 			Description: "parse i16",
 			Source:      *new(Foo16),
-			Expected:    &parser.Scalar{"Foo16", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Foo16", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: false, BitSize: 16, Named: true},
 		},
 		{
 			Description: "parse i32",
 			Source:      *new(Foo32),
-			Expected:    &parser.Scalar{"Foo32", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Foo32", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: false, BitSize: 32, Named: true},
 		},
 		{
 			Description: "parse i64",
 			Source:      *new(Foo64),
-			Expected:    &parser.Scalar{"Foo64", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Foo64", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: false, BitSize: 64, Named: true},
+		},
+		{
+			Description: "parse u8",
+			Source:      *new(UFoo8),
+			Expected: &parser.Scalar{
+				ItemName: "UFoo8",
+				PkgPath:  testPkgPath,
+				ItemType: parser.TypeInteger,
+				Nullable: false,
+				BitSize:  8,
+				Unsigned: true,
+				Named:    true,
+			},
+		},
+		{
+			Description: "parse u64",
+			Source:      *new(UFoo64),
+			Expected: &parser.Scalar{
+				ItemName: "UFoo64",
+				PkgPath:  testPkgPath,
+				ItemType: parser.TypeInteger,
+				Nullable: false,
+				BitSize:  64,
+				Unsigned: true,
+				Named:    true,
+			},
 		},
 		{
 			Description: "parse f32",
 			Source:      *new(Float32),
-			Expected:    &parser.Scalar{"Float32", parser.TypeFloat, false},
+			Expected:    &parser.Scalar{ItemName: "Float32", PkgPath: testPkgPath, ItemType: parser.TypeFloat, Nullable: false, BitSize: 32, Named: true},
 		},
 		{
 			Description: "parse f64",
 			Source:      *new(Float64),
-			Expected:    &parser.Scalar{"Float64", parser.TypeFloat, false},
+			Expected:    &parser.Scalar{ItemName: "Float64", PkgPath: testPkgPath, ItemType: parser.TypeFloat, Nullable: false, BitSize: 64, Named: true},
 		},
 		{
 			Description: "parse string",
 			Source:      *new(Language),
-			Expected:    &parser.Scalar{"Language", parser.TypeString, false},
+			Expected:    &parser.Scalar{ItemName: "Language", PkgPath: testPkgPath, ItemType: parser.TypeString, Nullable: false, Named: true},
 		},
 		{
 			Description: "parse boolean",
 			Source:      *new(IsEnabled),
-			Expected:    &parser.Scalar{"IsEnabled", parser.TypeBoolean, false},
+			Expected:    &parser.Scalar{ItemName: "IsEnabled", PkgPath: testPkgPath, ItemType: parser.TypeBoolean, Nullable: false, Named: true},
 		},
 	}
 
@@ -144,50 +181,55 @@ func Test_ParseItem_Map(t *testing.T) {
 			Description: "parse <string, string> map",
 			Source:      StringString{},
 			Expected: &parser.Map{
-				"StringString",
-				&parser.Scalar{"string", parser.TypeString, false},
-				&parser.Scalar{"string", parser.TypeString, false},
-				false,
+				ItemName: "StringString",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Nullable: false,
 			},
 		},
 		{
 			Description: "parse <string, int> map",
 			Source:      StringInt{},
 			Expected: &parser.Map{
-				"StringInt",
-				&parser.Scalar{"string", parser.TypeString, false},
-				&parser.Scalar{"int", parser.TypeInteger, false},
-				false,
+				ItemName: "StringInt",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Value:    &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
+				Nullable: false,
 			},
 		},
 		{
 			Description: "parse <string, float32> map",
 			Source:      StringFloat{},
 			Expected: &parser.Map{
-				"StringFloat",
-				&parser.Scalar{"string", parser.TypeString, false},
-				&parser.Scalar{"float32", parser.TypeFloat, false},
-				false,
+				ItemName: "StringFloat",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Value:    &parser.Scalar{ItemName: "float32", ItemType: parser.TypeFloat, Nullable: false, BitSize: 32},
+				Nullable: false,
 			},
 		},
 		{
 			Description: "parse <*string, *string> map",
 			Source:      PtrStr{},
 			Expected: &parser.Map{
-				"PtrStr",
-				&parser.Scalar{"string", parser.TypeString, true},
-				&parser.Scalar{"string", parser.TypeString, true},
-				false,
+				ItemName: "PtrStr",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
+				Nullable: false,
 			},
 		},
 		{
 			Description: "parse <string, *string> map",
 			Source:      ValuePtrStr{},
 			Expected: &parser.Map{
-				"ValuePtrStr",
-				&parser.Scalar{"string", parser.TypeString, false},
-				&parser.Scalar{"string", parser.TypeString, true},
-				false,
+				ItemName: "ValuePtrStr",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
+				Nullable: false,
 			},
 		},
 	}
@@ -223,11 +265,12 @@ func Test_ParseItem_Struct(t *testing.T) {
 			Description: "parse Person struct",
 			Source:      Person{},
 			Expected: &parser.Struct{
-				"Person",
-				[]parser.Field{
+				ItemName: "Person",
+				PkgPath:  testPkgPath,
+				Fields: []parser.Field{
 					{
 						ItemName: "FirstName",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "FirstName",
 							Name:         "FirstName",
@@ -239,7 +282,7 @@ func Test_ParseItem_Struct(t *testing.T) {
 
 					{
 						ItemName: "LastName",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "LastName",
 							Name:         "LastName",
@@ -249,7 +292,7 @@ func Test_ParseItem_Struct(t *testing.T) {
 						},
 					},
 				},
-				false,
+				Nullable: false,
 			},
 		},
 
@@ -258,13 +301,15 @@ func Test_ParseItem_Struct(t *testing.T) {
 			Source:      User{},
 			Expected: &parser.Struct{
 				ItemName: "User",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "full_name",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, true},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
 						Meta: meta.Meta{
 							OriginalName: "FullName",
 							Name:         "full_name",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalFalse,
 							Skip:         false,
@@ -273,10 +318,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 
 					{
 						ItemName: "uname",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Username",
 							Name:         "uname",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -285,10 +331,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 
 					{
 						ItemName: "pass",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Password",
 							Name:         "pass",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -304,18 +351,21 @@ func Test_ParseItem_Struct(t *testing.T) {
 			Source:      Account{},
 			Expected: &parser.Struct{
 				ItemName: "Account",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "linked_user",
 						BaseItem: &parser.Struct{
 							ItemName: "User",
+							PkgPath:  testPkgPath,
 							Fields: []parser.Field{
 								{
 									ItemName: "full_name",
-									BaseItem: &parser.Scalar{"string", parser.TypeString, true},
+									BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
 									Meta: meta.Meta{
 										OriginalName: "FullName",
 										Name:         "full_name",
+										ExplicitName: true,
 										Type:         "",
 										Optional:     meta.OptionalFalse,
 										Skip:         false,
@@ -323,10 +373,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 								},
 								{
 									ItemName: "uname",
-									BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+									BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 									Meta: meta.Meta{
 										OriginalName: "Username",
 										Name:         "uname",
+										ExplicitName: true,
 										Type:         "",
 										Optional:     meta.OptionalNone,
 										Skip:         false,
@@ -335,10 +386,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 
 								{
 									ItemName: "pass",
-									BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+									BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 									Meta: meta.Meta{
 										OriginalName: "Password",
 										Name:         "pass",
+										ExplicitName: true,
 										Type:         "",
 										Optional:     meta.OptionalNone,
 										Skip:         false,
@@ -350,6 +402,7 @@ func Test_ParseItem_Struct(t *testing.T) {
 						Meta: meta.Meta{
 							OriginalName: "User",
 							Name:         "linked_user",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -358,10 +411,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 
 					{
 						ItemName: "created_at",
-						BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+						BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 						Meta: meta.Meta{
 							OriginalName: "CreatedAt",
 							Name:         "created_at",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -377,10 +431,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 			Source:      Meta{},
 			Expected: &parser.Struct{
 				ItemName: "Meta",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "ID",
-						BaseItem: &parser.Scalar{"int32", parser.TypeInteger, false},
+						BaseItem: &parser.Scalar{ItemName: "int32", ItemType: parser.TypeInteger, Nullable: false, BitSize: 32},
 						Meta: meta.Meta{
 							OriginalName: "ID",
 							Name:         "ID",
@@ -391,10 +446,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 					},
 					{
 						ItemName: "scope",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Scope",
 							Name:         "scope",
+							ExplicitName: true,
 							Type:         "'reset' | 'change'",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -402,10 +458,11 @@ func Test_ParseItem_Struct(t *testing.T) {
 					},
 					{
 						ItemName: "created_at",
-						BaseItem: &parser.Scalar{"Time", parser.TypeTimestamp, false},
+						BaseItem: &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeTimestamp, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "CreatedAt",
 							Name:         "created_at",
+							ExplicitName: true,
 							Type:         "Date",
 							Optional:     meta.OptionalTrue,
 							Skip:         true,
@@ -444,7 +501,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      Strings{},
 			Expected: &parser.List{
 				ItemName: "Strings",
-				BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 				Nullable: false,
 				Length:   parser.EmptyLength,
 			},
@@ -456,7 +514,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      Ints{},
 			Expected: &parser.List{
 				ItemName: "Ints",
-				BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 				Nullable: false,
 				Length:   parser.EmptyLength,
 			},
@@ -468,7 +527,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      Floats{},
 			Expected: &parser.List{
 				ItemName: "Floats",
-				BaseItem: &parser.Scalar{"float32", parser.TypeFloat, false},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "float32", ItemType: parser.TypeFloat, Nullable: false, BitSize: 32},
 				Nullable: false,
 				Length:   parser.EmptyLength,
 			},
@@ -480,12 +540,14 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      Structs{},
 			Expected: &parser.List{
 				ItemName: "Structs",
+				PkgPath:  testPkgPath,
 				BaseItem: &parser.Struct{
 					ItemName: "CustomType",
+					PkgPath:  testPkgPath,
 					Fields: []parser.Field{
 						{
 							ItemName: "Name",
-							BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+							BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 							Meta: meta.Meta{
 								OriginalName: "Name",
 								Name:         "Name",
@@ -507,7 +569,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      StringPtrs{},
 			Expected: &parser.List{
 				ItemName: "StringPtrs",
-				BaseItem: &parser.Scalar{"string", parser.TypeString, true},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
 				Nullable: false,
 				Length:   parser.EmptyLength,
 			},
@@ -519,9 +582,10 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      ListList{},
 			Expected: &parser.List{
 				ItemName: "ListList",
+				PkgPath:  testPkgPath,
 				BaseItem: &parser.List{
 					ItemName: "", // The inner list has no name
-					BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+					BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 					Length:   parser.EmptyLength,
 					Nullable: false,
 				},
@@ -536,7 +600,7 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      *new(ListPtr), // new(ListPtr) returns a pointer to a nil slice, that is intentionally unhandled by the parser and will return an error for now
 			Expected: &parser.List{
 				ItemName: "",
-				BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+				BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 				Nullable: true,
 				Length:   parser.EmptyLength,
 			},
@@ -548,10 +612,11 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      ListPtrs{},
 			Expected: &parser.List{
 				ItemName: "ListPtrs",
+				PkgPath:  testPkgPath,
 				Length:   parser.EmptyLength,
 				BaseItem: &parser.List{
 					ItemName: "",
-					BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+					BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 					Length:   parser.EmptyLength,
 					Nullable: true,
 				},
@@ -564,7 +629,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      FixedStrings{},
 			Expected: &parser.List{
 				ItemName: "FixedStrings",
-				BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 				Length:   3,
 				Nullable: false,
 			},
@@ -576,12 +642,14 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      FixedStructs{},
 			Expected: &parser.List{
 				ItemName: "FixedStructs",
+				PkgPath:  testPkgPath,
 				BaseItem: &parser.Struct{
 					ItemName: "CustomType",
+					PkgPath:  testPkgPath,
 					Fields: []parser.Field{
 						{
 							ItemName: "Name",
-							BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+							BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 							Meta: meta.Meta{
 								OriginalName: "Name",
 								Name:         "Name",
@@ -603,7 +671,8 @@ func Test_ParseItem_List(t *testing.T) {
 			Source:      FixedIntPtrs{},
 			Expected: &parser.List{
 				ItemName: "FixedIntPtrs",
-				BaseItem: &parser.Scalar{"int", parser.TypeInteger, true},
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: true, BitSize: 64},
 				Length:   6,
 				Nullable: false,
 			},
@@ -630,8 +699,9 @@ func Test_ParseItem_Function(t *testing.T) {
 			Source:      Func1(nil),
 			Expected: &parser.Function{
 				ItemName: "Func1",
+				PkgPath:  testPkgPath,
 				Params:   []parser.Item{},
-				Returns:  []parser.Item{&parser.Scalar{"error", parser.TypeString, false}},
+				Returns:  []parser.Item{&parser.Scalar{ItemName: "error", ItemType: parser.TypeString, Nullable: false}},
 				Nullable: false,
 			},
 		},
@@ -642,11 +712,12 @@ func Test_ParseItem_Function(t *testing.T) {
 			Source:      Add(nil),
 			Expected: &parser.Function{
 				ItemName: "Add",
+				PkgPath:  testPkgPath,
 				Params: []parser.Item{
-					&parser.Scalar{"int", parser.TypeInteger, false},
-					&parser.Scalar{"int", parser.TypeInteger, false},
+					&parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
+					&parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 				},
-				Returns:  []parser.Item{&parser.Scalar{"int", parser.TypeInteger, false}},
+				Returns:  []parser.Item{&parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64}},
 				Nullable: false,
 			},
 		},
@@ -657,13 +728,14 @@ func Test_ParseItem_Function(t *testing.T) {
 			Source:      ReturnMultiple(nil),
 			Expected: &parser.Function{
 				ItemName: "ReturnMultiple",
+				PkgPath:  testPkgPath,
 				Params: []parser.Item{
-					&parser.Scalar{"string", parser.TypeString, false},
-					&parser.Scalar{"string", parser.TypeString, true},
+					&parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+					&parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: true},
 				},
 				Returns: []parser.Item{
-					&parser.Scalar{"int", parser.TypeInteger, false},
-					&parser.Scalar{"error", parser.TypeString, false},
+					&parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
+					&parser.Scalar{ItemName: "error", ItemType: parser.TypeString, Nullable: false},
 				},
 				Nullable: false,
 			},
@@ -675,13 +747,15 @@ func Test_ParseItem_Function(t *testing.T) {
 			Source:      InsertFoo(nil),
 			Expected: &parser.Function{
 				ItemName: "InsertFoo",
+				PkgPath:  testPkgPath,
 				Params: []parser.Item{
 					&parser.Struct{
 						ItemName: "Foo",
+						PkgPath:  testPkgPath,
 						Fields: []parser.Field{
 							{
 								ItemName: "Name",
-								BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+								BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 								Meta: meta.Meta{
 									OriginalName: "Name",
 									Name:         "Name",
@@ -694,7 +768,7 @@ func Test_ParseItem_Function(t *testing.T) {
 						Nullable: false,
 					},
 				},
-				Returns:  []parser.Item{&parser.Scalar{"error", parser.TypeString, false}},
+				Returns:  []parser.Item{&parser.Scalar{ItemName: "error", ItemType: parser.TypeString, Nullable: false}},
 				Nullable: false,
 			},
 		},
@@ -721,16 +795,29 @@ func Test_ParseEmbeddedStruct(t *testing.T) {
 		}
 	)
 
+	fooEmbedded := &parser.Struct{
+		ItemName: "FooEmbedded",
+		PkgPath:  testPkgPath,
+		Fields: []parser.Field{
+			{
+				ItemName: "Name",
+				BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Meta:     meta.Meta{OriginalName: "Name", Name: "Name", Optional: meta.OptionalNone},
+			},
+		},
+	}
+
 	tests := []Test{
 		{
 			Description: "parse embedded struct",
 			Source:      FooParent{},
 			Expected: &parser.Struct{
 				ItemName: "FooParent",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "Name",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Name",
 							Name:         "Name",
@@ -738,10 +825,11 @@ func Test_ParseEmbeddedStruct(t *testing.T) {
 							Optional:     meta.OptionalNone,
 							Skip:         false,
 						},
+						EmbeddedFrom: fooEmbedded,
 					},
 					{
 						ItemName: "Age",
-						BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+						BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 						Meta: meta.Meta{
 							OriginalName: "Age",
 							Name:         "Age",
@@ -759,13 +847,15 @@ func Test_ParseEmbeddedStruct(t *testing.T) {
 			Source:      FooWithEmbeddedString{},
 			Expected: &parser.Struct{
 				ItemName: "FooWithEmbeddedString",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "embedded_string",
-						BaseItem: &parser.Scalar{"EmbeddedString", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "EmbeddedString", PkgPath: testPkgPath, ItemType: parser.TypeString, Nullable: false, Named: true},
 						Meta: meta.Meta{
 							OriginalName: "EmbeddedString",
 							Name:         "embedded_string",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -774,7 +864,7 @@ func Test_ParseEmbeddedStruct(t *testing.T) {
 
 					{
 						ItemName: "EmbeddedInt",
-						BaseItem: &parser.Scalar{"EmbeddedInt", parser.TypeInteger, true},
+						BaseItem: &parser.Scalar{ItemName: "EmbeddedInt", PkgPath: testPkgPath, ItemType: parser.TypeInteger, Nullable: true, BitSize: 64, Named: true},
 						Meta: meta.Meta{
 							OriginalName: "EmbeddedInt",
 							Name:         "EmbeddedInt",
@@ -786,10 +876,11 @@ func Test_ParseEmbeddedStruct(t *testing.T) {
 
 					{
 						ItemName: "probably",
-						BaseItem: &parser.Scalar{"EmbeddedBool", parser.TypeBoolean, true},
+						BaseItem: &parser.Scalar{ItemName: "EmbeddedBool", PkgPath: testPkgPath, ItemType: parser.TypeBoolean, Nullable: true, Named: true},
 						Meta: meta.Meta{
 							OriginalName: "EmbeddedBool",
 							Name:         "probably",
+							ExplicitName: true,
 							Type:         "number",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -813,23 +904,24 @@ func Test_ParseBuiltInTypes(t *testing.T) {
 		{
 			Description: "parse time.Time",
 			Source:      time.Time{},
-			Expected:    &parser.Scalar{"Time", parser.TypeTimestamp, false},
+			Expected:    &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeTimestamp, Nullable: false},
 		},
 
 		{
 			Description: "parse nullable time.Time",
 			Source:      &time.Time{},
-			Expected:    &parser.Scalar{"Time", parser.TypeTimestamp, true},
+			Expected:    &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeTimestamp, Nullable: true},
 		},
 
 		{
 			Description: "parse []time.Time",
 			Source:      TimeSlice{},
 			Expected: &parser.List{
-				"TimeSlice",
-				&parser.Scalar{"Time", parser.TypeTimestamp, false},
-				false,
-				parser.EmptyLength,
+				ItemName: "TimeSlice",
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeTimestamp, Nullable: false},
+				Nullable: false,
+				Length:   parser.EmptyLength,
 			},
 		},
 
@@ -837,35 +929,36 @@ func Test_ParseBuiltInTypes(t *testing.T) {
 			Description: "parse []time.Time",
 			Source:      &TimeArray{},
 			Expected: &parser.List{
-				"TimeArray",
-				&parser.Scalar{"Time", parser.TypeTimestamp, false},
-				true,
-				3,
+				ItemName: "TimeArray",
+				PkgPath:  testPkgPath,
+				BaseItem: &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeTimestamp, Nullable: false},
+				Nullable: true,
+				Length:   3,
 			},
 		},
 
 		{
 			Description: "parse time.Duration",
 			Source:      time.Duration(0),
-			Expected:    &parser.Scalar{"Duration", parser.TypeInteger, false},
+			Expected:    &parser.Scalar{ItemName: "Duration", PkgPath: "time", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64, Named: true},
 		},
 
 		{
 			Description: "parse nullable time.Duration",
 			Source:      new(time.Duration),
-			Expected:    &parser.Scalar{"Duration", parser.TypeInteger, true},
+			Expected:    &parser.Scalar{ItemName: "Duration", PkgPath: "time", ItemType: parser.TypeInteger, Nullable: true, BitSize: 64, Named: true},
 		},
 
 		{
 			Description: "parse sql.NullTime",
 			Source:      sql.NullTime{},
-			Expected:    &parser.Scalar{"NullTime", parser.TypeTimestamp, true},
+			Expected:    &parser.Scalar{ItemName: "NullTime", PkgPath: "database/sql", ItemType: parser.TypeTimestamp, Nullable: true},
 		},
 
 		{
 			Description: "parse sql.NullInt64",
 			Source:      sql.NullInt64{},
-			Expected:    &parser.Scalar{"NullInt64", parser.TypeInteger, true},
+			Expected:    &parser.Scalar{ItemName: "NullInt64", PkgPath: "database/sql", ItemType: parser.TypeInteger, Nullable: true, BitSize: 64},
 		},
 	}
 
@@ -894,10 +987,11 @@ func Test_ParserHooks(t *testing.T) {
 			Source:      TargetFoo{},
 			Expected: &parser.Struct{
 				ItemName: "TargetFoo",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "Name",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Name",
 							Name:         "Name",
@@ -910,7 +1004,7 @@ func Test_ParserHooks(t *testing.T) {
 					// Added dynamically
 					{
 						ItemName: "Age",
-						BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+						BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 						Meta: meta.Meta{
 							OriginalName: "Age",
 							Name:         "AddedAge",
@@ -928,10 +1022,11 @@ func Test_ParserHooks(t *testing.T) {
 			Source:      NotTargetFoo{},
 			Expected: &parser.Struct{
 				ItemName: "NotTargetFoo",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "Name",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "Name",
 							Name:         "Name",
@@ -949,13 +1044,15 @@ func Test_ParserHooks(t *testing.T) {
 			Source:      Person{},
 			Expected: &parser.Struct{
 				ItemName: "Person",
+				PkgPath:  testPkgPath,
 				Fields: []parser.Field{
 					{
 						ItemName: "FName",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "FirstName",
 							Name:         "FName",
+							ExplicitName: true,
 							Type:         "",
 							Optional:     meta.OptionalNone,
 							Skip:         false,
@@ -964,7 +1061,7 @@ func Test_ParserHooks(t *testing.T) {
 
 					{
 						ItemName: "LastName",
-						BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+						BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 						Meta: meta.Meta{
 							OriginalName: "LastName",
 							Name:         "LastName",
@@ -986,7 +1083,7 @@ func Test_ParserHooks(t *testing.T) {
 		if name == "TargetFoo" {
 			item.(*parser.Struct).Fields = append(item.(*parser.Struct).Fields, parser.Field{
 				ItemName: "Age",
-				BaseItem: &parser.Scalar{"int", parser.TypeInteger, false},
+				BaseItem: &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, Nullable: false, BitSize: 64},
 				Meta: meta.Meta{
 					OriginalName: "Age",
 					Name:         "AddedAge",
@@ -1112,14 +1209,14 @@ func Test_ParseCustomItem(t *testing.T) {
 	)
 
 	var (
-		internalScalarItem = &parser.Scalar{"overriden_scalar_type", parser.TypeVoid, false}
+		internalScalarItem = &parser.Scalar{ItemName: "overriden_scalar_type", ItemType: parser.TypeVoid, Nullable: false}
 
 		internalStructItem = &parser.Struct{
 			ItemName: "overriden_struct_type",
 			Fields: []parser.Field{
 				{
 					ItemName: "name",
-					BaseItem: &parser.Scalar{"string", parser.TypeString, false},
+					BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
 					Meta:     meta.Meta{},
 				},
 			},
@@ -1142,7 +1239,7 @@ func Test_ParseCustomItem(t *testing.T) {
 		{
 			Description: "parse unregistered custom type",
 			Source:      __internal_unregistered_type(""),
-			Expected:    &parser.Scalar{"__internal_unregistered_type", parser.TypeString, false},
+			Expected:    &parser.Scalar{ItemName: "__internal_unregistered_type", PkgPath: testPkgPath, ItemType: parser.TypeString, Nullable: false, Named: true},
 		},
 	}
 
@@ -1158,6 +1255,713 @@ func Test_ParseCustomItem(t *testing.T) {
 	runTests(t, tests, p)
 }
 
+type (
+	Event interface{ isEvent() }
+
+	EventCreated struct {
+		ID string `json:"id"`
+	}
+
+	EventDeleted struct {
+		ID     string `json:"id"`
+		Reason string `json:"reason"`
+	}
+)
+
+func (EventCreated) isEvent()  {}
+func (*EventDeleted) isEvent() {}
+
+func Test_ParseUnion(t *testing.T) {
+	type Envelope struct {
+		Event Event `json:"event"`
+	}
+
+	idField := parser.Field{
+		ItemName: "id",
+		BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+		Meta:     meta.Meta{OriginalName: "ID", Name: "id", ExplicitName: true},
+	}
+
+	expectedUnion := &parser.Union{
+		ItemName:      "Event",
+		PkgPath:       testPkgPath,
+		Discriminator: "type",
+		Variants: []parser.UnionVariant{
+			{
+				Tag:  "created",
+				Item: &parser.Struct{ItemName: "EventCreated", PkgPath: testPkgPath, Fields: []parser.Field{idField}},
+			},
+			{
+				Tag: "deleted",
+				Item: &parser.Struct{
+					ItemName: "EventDeleted",
+					PkgPath:  testPkgPath,
+					Fields: []parser.Field{
+						idField,
+						{
+							ItemName: "reason",
+							BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+							Meta:     meta.Meta{OriginalName: "Reason", Name: "reason", ExplicitName: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	p := parser.New()
+	err := p.AddUnion(
+		reflect.TypeOf((*Event)(nil)),
+		"type",
+		parser.VariantOf("created", EventCreated{}),
+		parser.VariantOf("deleted", &EventDeleted{}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := p.Parse(reflect.TypeOf((*Event)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expectedUnion) {
+		t.Errorf("wanted %#v, got %#v", expectedUnion, got)
+	}
+
+	runTests(t, []Test{
+		{
+			Description: "parse struct with union field",
+			Source:      Envelope{},
+			Expected: &parser.Struct{
+				ItemName: "Envelope",
+				PkgPath:  testPkgPath,
+				Fields: []parser.Field{
+					{
+						ItemName: "event",
+						BaseItem: expectedUnion,
+						Meta:     meta.Meta{OriginalName: "Event", Name: "event", ExplicitName: true},
+					},
+				},
+			},
+		},
+	}, p)
+
+	invalid := []struct {
+		Description   string
+		Interface     reflect.Type
+		Discriminator string
+		Variants      []parser.VariantSource
+	}{
+		{
+			Description:   "non-interface type",
+			Interface:     reflect.TypeOf(EventCreated{}),
+			Discriminator: "type",
+			Variants:      []parser.VariantSource{parser.VariantOf("created", EventCreated{})},
+		},
+		{
+			Description:   "empty discriminator",
+			Interface:     reflect.TypeOf((*Event)(nil)),
+			Discriminator: "",
+			Variants:      []parser.VariantSource{parser.VariantOf("created", EventCreated{})},
+		},
+		{
+			Description:   "duplicate tags",
+			Interface:     reflect.TypeOf((*Event)(nil)),
+			Discriminator: "type",
+			Variants: []parser.VariantSource{
+				parser.VariantOf("created", EventCreated{}),
+				parser.VariantOf("created", EventDeleted{}),
+			},
+		},
+		{
+			Description:   "variant does not implement interface",
+			Interface:     reflect.TypeOf((*Event)(nil)),
+			Discriminator: "type",
+			Variants:      []parser.VariantSource{parser.VariantOf("envelope", Envelope{})},
+		},
+	}
+
+	for _, tt := range invalid {
+		if err := parser.New().AddUnion(tt.Interface, tt.Discriminator, tt.Variants...); err == nil {
+			t.Errorf("[%s] wanted error, got no error", tt.Description)
+		}
+	}
+
+	// The variants passed in must neither be modified nor shared with the parser
+	variants := []parser.VariantSource{parser.VariantOf("created", EventCreated{}), parser.VariantOf("deleted", &EventDeleted{})}
+
+	shared := parser.New()
+	if err := shared.AddUnion(reflect.TypeOf((*Event)(nil)), "type", variants...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if variants[1].Source != reflect.TypeOf(&EventDeleted{}) {
+		t.Errorf("expected the variants passed in to be left untouched, got source `%s`", variants[1].Source)
+	}
+
+	variants[0] = parser.VariantOf("renamed", EventCreated{})
+
+	got, err = shared.Parse(reflect.TypeOf((*Event)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expectedUnion) {
+		t.Errorf("expected the registered variants to be unaffected by changes to the slice passed in, got %#v", got)
+	}
+}
+
+type TreeNode struct {
+	Value    string      `json:"value"`
+	Parent   *TreeNode   `json:"parent"`
+	Children []*TreeNode `json:"children"`
+}
+
+func Test_ParseRecursiveTypes(t *testing.T) {
+	placeholder := &parser.Struct{ItemName: "TreeNode", PkgPath: testPkgPath, Nullable: true}
+
+	expected := &parser.Struct{
+		ItemName: "TreeNode",
+		PkgPath:  testPkgPath,
+		Fields: []parser.Field{
+			{
+				ItemName: "value",
+				BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString, Nullable: false},
+				Meta:     meta.Meta{OriginalName: "Value", Name: "value", ExplicitName: true},
+			},
+			{
+				ItemName: "parent",
+				BaseItem: placeholder,
+				Meta:     meta.Meta{OriginalName: "Parent", Name: "parent", ExplicitName: true},
+			},
+			{
+				ItemName: "children",
+				BaseItem: &parser.List{ItemName: "", BaseItem: placeholder, Length: parser.EmptyLength},
+				Meta:     meta.Meta{OriginalName: "Children", Name: "children", ExplicitName: true},
+			},
+		},
+	}
+
+	for _, caching := range []bool{true, false} {
+		p := parser.New()
+		p.SetEnableCaching(caching)
+
+		// Parse twice to make sure the cached value is also complete
+		for i := 0; i < 2; i++ {
+			got, err := p.Parse(reflect.TypeOf(TreeNode{}))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("[caching: %v] wanted %#v, got %#v", caching, expected, got)
+			}
+		}
+	}
+}
+
+func Test_ParseAll(t *testing.T) {
+	type (
+		Foo string
+		Bar struct {
+			Foo  Foo       `json:"foo"`
+			Tree *TreeNode `json:"tree"`
+		}
+		Baz []Bar
+	)
+
+	sources := []reflect.Type{
+		reflect.TypeOf(Foo("")),
+		reflect.TypeOf(Bar{}),
+		reflect.TypeOf(Baz{}),
+		reflect.TypeOf(TreeNode{}),
+		reflect.TypeOf(map[string]Bar{}),
+	}
+
+	// Without caching, every source is parsed on its own, so the result does not depend on the order sources are parsed in
+	uncached := parser.New().SetEnableCaching(false)
+	if err := uncached.AddSources(sources...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var expected []parser.Item
+	err := uncached.Iterate(func(item parser.Item) error {
+		expected = append(expected, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Parsing the sources in reverse fills the cache in a different order, the result must be the same
+	reversed := parser.New()
+	for i := len(sources) - 1; i >= 0; i-- {
+		if _, err := reversed.Parse(sources[i]); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if err := reversed.AddSources(sources...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, workers := range []int{0, 1, 3, 16} {
+		// Repeat the runs since the order workers parse sources in changes between runs
+		for run := 0; run < 5; run++ {
+			p := parser.New()
+			if err := p.AddSources(sources...); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := p.ParseAll(workers)
+			if err != nil {
+				t.Fatalf("[workers: %d] unexpected error: %s", workers, err)
+			}
+
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatalf("[workers: %d] wanted %#v, got %#v", workers, expected, got)
+			}
+		}
+	}
+
+	got, err := reversed.ParseAll(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("[reversed] wanted %#v, got %#v", expected, got)
+	}
+
+	// Sources that cannot be parsed should return an error
+	p := parser.New()
+	if err := p.AddSources(append(sources, reflect.TypeOf(make(chan int)))...); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := p.ParseAll(4); err == nil {
+		t.Errorf("wanted error, got no error")
+	}
+
+	// The error of the earliest failing source is returned, regardless of which source fails first
+	type (
+		Early chan int
+		Late  chan string
+	)
+
+	failing := append([]reflect.Type{reflect.TypeOf(Early(nil))}, sources...)
+	failing = append(failing, reflect.TypeOf(Late(nil)))
+
+	for run := 0; run < 10; run++ {
+		p := parser.New()
+		if err := p.AddSources(failing...); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if _, err := p.ParseAll(len(failing)); err == nil || !strings.Contains(err.Error(), "`Early`") {
+			t.Fatalf("wanted the error of `Early`, got %v", err)
+		}
+	}
+}
+
+func Test_ConcurrentParsing(t *testing.T) {
+	type Foo struct {
+		Name     string         `json:"name"`
+		Tags     map[string]int `json:"tags"`
+		Children []*TreeNode    `json:"children"`
+	}
+
+	p := parser.New()
+	if err := p.AddSources(reflect.TypeOf(Foo{}), reflect.TypeOf(TreeNode{})); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := p.Iterate(func(item parser.Item) error {
+				if _, ok := p.LookupByName(item.Name()); !ok {
+					t.Errorf("expected to find %s", item.Name())
+				}
+
+				return nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if _, err := p.ParseAll(2); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func Test_ParseWithExtractors(t *testing.T) {
+	type Config struct {
+		Name    string `json:"name"         yaml:"display_name"`
+		Port    int    `json:"port"         yaml:"port,omitempty"`
+		Secret  string `json:"secret"       yaml:"-"`
+		Verbose bool   `yaml:"verbose"      mirror:"name:is_verbose"`
+		Form    string `form:"form_value,omitempty"`
+	}
+
+	field := func(itemName string, base parser.Item, m meta.Meta) parser.Field {
+		return parser.Field{ItemName: itemName, BaseItem: base, Meta: m}
+	}
+
+	str := &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}
+
+	p := parser.New()
+	if err := p.AddExtractor(extractor.YAML()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tags := make([]string, 0)
+	for _, e := range p.Extractors() {
+		tags = append(tags, e.Tag())
+	}
+
+	if expected := []string{"json", "yaml", "validate", "mirror"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("expected extractors %v, got %v", expected, tags)
+	}
+
+	runTests(t, []Test{
+		{
+			Description: "parse struct with yaml extractor added",
+			Source:      Config{},
+			Expected: &parser.Struct{
+				ItemName: "Config",
+				PkgPath:  testPkgPath,
+				Fields: []parser.Field{
+					field("display_name", str, meta.Meta{OriginalName: "Name", Name: "display_name", ExplicitName: true}),
+					field("port", &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, BitSize: 64}, meta.Meta{OriginalName: "Port", Name: "port", ExplicitName: true, Optional: meta.OptionalTrue}),
+					field("secret", str, meta.Meta{OriginalName: "Secret", Name: "secret", ExplicitName: true, Skip: true}),
+					field("is_verbose", &parser.Scalar{ItemName: "bool", ItemType: parser.TypeBoolean}, meta.Meta{OriginalName: "Verbose", Name: "is_verbose", ExplicitName: true}),
+					field("Form", str, meta.Meta{OriginalName: "Form", Name: "Form"}),
+				},
+			},
+		},
+	}, p)
+
+	p = parser.New()
+	if err := p.SetExtractors(extractor.ForTag("form", "omitempty")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	runTests(t, []Test{
+		{
+			Description: "parse struct with only the form extractor",
+			Source:      Config{},
+			Expected: &parser.Struct{
+				ItemName: "Config",
+				PkgPath:  testPkgPath,
+				Fields: []parser.Field{
+					field("Name", str, meta.Meta{OriginalName: "Name", Name: "Name"}),
+					field("Port", &parser.Scalar{ItemName: "int", ItemType: parser.TypeInteger, BitSize: 64}, meta.Meta{OriginalName: "Port", Name: "Port"}),
+					field("Secret", str, meta.Meta{OriginalName: "Secret", Name: "Secret"}),
+					field("Verbose", &parser.Scalar{ItemName: "bool", ItemType: parser.TypeBoolean}, meta.Meta{OriginalName: "Verbose", Name: "Verbose"}),
+					field("form_value", str, meta.Meta{OriginalName: "Form", Name: "form_value", ExplicitName: true, Optional: meta.OptionalTrue}),
+				},
+			},
+		},
+	}, p)
+
+	if err := p.SetExtractors(nil); err == nil {
+		t.Errorf("wanted error, got no error")
+	}
+}
+
+func Test_ParseFixedLengthArrays(t *testing.T) {
+	type Point struct {
+		Coordinates [3]float64 `json:"coordinates"`
+		Bounds      [2]float64 `json:"bounds" validate:"min=1"`
+		Tags        []string   `json:"tags"`
+	}
+
+	three, one := 3, 1
+	float := &parser.Scalar{ItemName: "float64", ItemType: parser.TypeFloat, BitSize: 64}
+
+	runTests(t, []Test{
+		{
+			Description: "parse fixed-length arrays with length constraints",
+			Source:      Point{},
+			Expected: &parser.Struct{
+				ItemName: "Point",
+				PkgPath:  testPkgPath,
+				Fields: []parser.Field{
+					{
+						ItemName: "coordinates",
+						BaseItem: &parser.List{BaseItem: float, Length: 3},
+						Meta: meta.Meta{
+							OriginalName: "Coordinates",
+							Name:         "coordinates",
+							ExplicitName: true,
+							Constraints:  meta.Constraints{MinLength: &three, MaxLength: &three},
+						},
+					},
+					{
+						ItemName: "bounds",
+						BaseItem: &parser.List{BaseItem: float, Length: 2},
+						Meta: meta.Meta{
+							OriginalName: "Bounds",
+							Name:         "bounds",
+							ExplicitName: true,
+							Constraints:  meta.Constraints{MinLength: &one},
+						},
+					},
+					{
+						ItemName: "tags",
+						BaseItem: &parser.List{BaseItem: &parser.Scalar{ItemName: "string", ItemType: parser.TypeString}, Length: parser.EmptyLength},
+						Meta:     meta.Meta{OriginalName: "Tags", Name: "tags", ExplicitName: true},
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_ParseWithDefaults(t *testing.T) {
+	type (
+		Base struct {
+			Version int `json:"version"`
+		}
+
+		Config struct {
+			Base
+			Host    string   `json:"host"`
+			Port    int      `json:"port" mirror:"default:9090"`
+			Tags    []string `json:"tags"`
+			Timeout *int     `json:"timeout"`
+		}
+	)
+
+	p := parser.New()
+	p.SetFlattenEmbeddedTypes(true)
+
+	if err := p.AddSourceWithDefaults(&Config{Base: Base{Version: 2}, Host: "localhost", Port: 8080, Tags: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := p.AddSourceWithDefaults(nil); err == nil {
+		t.Errorf("wanted error for nil source, got no error")
+	}
+
+	if err := p.AddSourceWithDefaults(42); err == nil {
+		t.Errorf("wanted error for non-struct source, got no error")
+	}
+
+	item, err := p.ParseN(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !item.IsNullable() {
+		t.Errorf("expected the source to keep its pointer type")
+	}
+
+	expected := map[string]string{
+		"version": "2",
+		"host":    `"localhost"`,
+		"port":    "9090",
+		"tags":    `["a"]`,
+		"timeout": "null",
+	}
+
+	for _, field := range item.(*parser.Struct).Fields {
+		if field.Meta.Default != expected[field.ItemName] {
+			t.Errorf("expected default of `%s` to be %s, got %s", field.ItemName, expected[field.ItemName], field.Meta.Default)
+		}
+	}
+}
+
+func Test_ParseParamNames(t *testing.T) {
+	type (
+		Request  struct{}
+		GetUser  func(context.Context, Request) (string, error)
+		Callback func(int)
+	)
+
+	p := parser.New()
+	if err := p.SetParamNames(reflect.TypeOf(GetUser(nil)), "ctx", "request"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	errorTests := []struct {
+		Description string
+		Source      reflect.Type
+		Names       []string
+	}{
+		{Description: "nil source", Source: nil, Names: []string{"a"}},
+		{Description: "non-function source", Source: reflect.TypeOf(Request{}), Names: []string{"a"}},
+		{Description: "wrong number of names", Source: reflect.TypeOf(Callback(nil)), Names: []string{"a", "b"}},
+		{Description: "invalid name", Source: reflect.TypeOf(Callback(nil)), Names: []string{"first-value"}},
+		{Description: "duplicate names", Source: reflect.TypeOf(GetUser(nil)), Names: []string{"a", "a"}},
+	}
+
+	for _, test := range errorTests {
+		if err := p.SetParamNames(test.Source, test.Names...); err == nil {
+			t.Errorf("[%s] wanted error, got no error", test.Description)
+		}
+	}
+
+	item, err := p.Parse(reflect.TypeOf(GetUser(nil)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fn := item.(*parser.Function)
+	if !reflect.DeepEqual(fn.ParamNames, []string{"ctx", "request"}) {
+		t.Errorf("expected parameter names [ctx request], got %v", fn.ParamNames)
+	}
+
+	if !parser.IsContext(fn.Params[0]) || parser.IsContext(fn.Params[1]) {
+		t.Errorf("expected only the first parameter to be a context")
+	}
+
+	if !parser.IsError(fn.Returns[1]) || parser.IsError(fn.Returns[0]) {
+		t.Errorf("expected only the last return value to be an error")
+	}
+
+	if item, err = p.Parse(reflect.TypeOf(Callback(nil))); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if name := item.(*parser.Function).ParamName(0); name != "" {
+		t.Errorf("expected unnamed parameter, got %q", name)
+	}
+}
+
+type (
+	Priority int
+	Role     string
+
+	// Coordinate is encoded as a string (e.g. `1,2`) when used as a map key
+	Coordinate struct {
+		X, Y int
+	}
+)
+
+func (c Coordinate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", c.X, c.Y)), nil
+}
+
+// Level is an integer encoded by name when used as a map key
+type Level int
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("level-%d", int(l))), nil
+}
+
+func Test_AddEnum(t *testing.T) {
+	p := parser.New()
+	if err := p.AddEnum(Role("admin"), Role("user")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := p.AddEnum(Priority(1), Priority(2)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	errorTests := []struct {
+		Description string
+		Values      []any
+	}{
+		{Description: "no values", Values: nil},
+		{Description: "nil value", Values: []any{nil}},
+		{Description: "non-scalar type", Values: []any{Coordinate{}}},
+		{Description: "built-in type", Values: []any{"admin"}},
+		{Description: "mixed types", Values: []any{Role("admin"), Priority(1)}},
+		{Description: "duplicate values", Values: []any{Role("admin"), Role("admin")}},
+	}
+
+	for _, test := range errorTests {
+		if err := p.AddEnum(test.Values...); err == nil {
+			t.Errorf("[%s] wanted error, got no error", test.Description)
+		}
+	}
+
+	if p.Count() != 2 {
+		t.Errorf("expected enums to be added as sources, got %d sources", p.Count())
+	}
+
+	tests := []Test{
+		{
+			Description: "parse string enum",
+			Source:      Role(""),
+			Expected:    &parser.Scalar{ItemName: "Role", PkgPath: testPkgPath, ItemType: parser.TypeString, Named: true, Enum: []string{`"admin"`, `"user"`}},
+		},
+		{
+			Description: "parse integer enum",
+			Source:      Priority(0),
+			Expected:    &parser.Scalar{ItemName: "Priority", PkgPath: testPkgPath, ItemType: parser.TypeInteger, BitSize: 64, Named: true, Enum: []string{"1", "2"}},
+		},
+	}
+
+	runTests(t, tests, p)
+}
+
+func Test_ParseMapKeys(t *testing.T) {
+	type (
+		ByCoordinate map[Coordinate]string
+		ByTime       map[time.Time]string
+		ByPriority   map[Priority]string
+		ByLevel      map[Level]string
+	)
+
+	tests := []Test{
+		{
+			Description: "parse text marshaler key as string",
+			Source:      ByCoordinate{},
+			Expected: &parser.Map{
+				ItemName: "ByCoordinate",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "Coordinate", PkgPath: testPkgPath, ItemType: parser.TypeString},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+			},
+		},
+		{
+			Description: "parse time key as string",
+			Source:      ByTime{},
+			Expected: &parser.Map{
+				ItemName: "ByTime",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "Time", PkgPath: "time", ItemType: parser.TypeString},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+			},
+		},
+		{
+			Description: "parse integer key",
+			Source:      ByPriority{},
+			Expected: &parser.Map{
+				ItemName: "ByPriority",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "Priority", PkgPath: testPkgPath, ItemType: parser.TypeInteger, BitSize: 64, Named: true},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+			},
+		},
+		{
+			Description: "parse integer text marshaler key as string",
+			Source:      ByLevel{},
+			Expected: &parser.Map{
+				ItemName: "ByLevel",
+				PkgPath:  testPkgPath,
+				Key:      &parser.Scalar{ItemName: "Level", PkgPath: testPkgPath, ItemType: parser.TypeString},
+				Value:    &parser.Scalar{ItemName: "string", ItemType: parser.TypeString},
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
 func runTests(t *testing.T, tests []Test, optParser ...*parser.Parser) {
 	for _, tt := range tests {
 		runTest(t, tt, optParser...)
@@ -0,0 +1,201 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/ir"
+	"go.trulyao.dev/mirror/v2/parser"
+	"go.trulyao.dev/mirror/v2/types"
+)
+
+// Generator runs a plugin to generate code, every generation method starts a new plugin process
+type Generator struct {
+	target *Target
+	parser types.ParserInterface
+	header string
+}
+
+// NewGenerator returns a new generator for the plugin target
+func NewGenerator(target *Target) *Generator {
+	return &Generator{target: target}
+}
+
+// SetParser sets the parser to use for generating the item tree sent to the plugin
+func (g *Generator) SetParser(parser types.ParserInterface) error {
+	if parser == nil {
+		return errors.New("parser cannot be nil")
+	}
+
+	g.parser = parser
+	return nil
+}
+
+// SetHeaderText sets the header text passed to the plugin in the request
+func (g *Generator) SetHeaderText(header string) {
+	g.header = header
+}
+
+// SetNonStrict is a no-op, plugins are responsible for their own checks
+func (g *Generator) SetNonStrict(bool) {}
+
+// GenerateFiles runs the plugin with every parsed source and returns the files it generated
+func (g *Generator) GenerateFiles() ([]types.File, error) {
+	if g.parser == nil {
+		return nil, errors.New("no parser provided")
+	}
+
+	var items []parser.Item
+	if err := g.parser.Iterate(func(item parser.Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return g.generate(items...)
+}
+
+// GenerateAll runs the plugin with every parsed source and returns the content of each generated file
+func (g *Generator) GenerateAll() ([]string, error) {
+	files, err := g.GenerateFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return fileContents(files), nil
+}
+
+// GenerateN runs the plugin with the nth parsed source only
+func (g *Generator) GenerateN(n int) (string, error) {
+	if g.parser == nil {
+		return "", errors.New("no parser provided")
+	}
+
+	item, err := g.parser.ParseN(n)
+	if err != nil {
+		return "", err
+	}
+
+	return g.GenerateItem(item)
+}
+
+// GenerateItem runs the plugin with a single item and returns the content of the generated file(s)
+func (g *Generator) GenerateItem(item parser.Item) (string, error) {
+	files, err := g.generate(item)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(fileContents(files), "\n\n"), nil
+}
+
+// GenerateItemType is not supported by plugins, since the protocol only deals with whole files
+func (g *Generator) GenerateItemType(parser.Item) (string, error) {
+	return "", errors.New("generating item types is not supported by plugins")
+}
+
+// generate runs the plugin with the provided items
+func (g *Generator) generate(items ...parser.Item) ([]types.File, error) {
+	request := Request{
+		Version:     ProtocolVersion,
+		Language:    g.target.Lang,
+		FileName:    g.target.Name(),
+		Prefix:      g.target.TypePrefix,
+		Header:      g.header,
+		CustomTypes: g.target.customTypes,
+		Options:     g.target.Options,
+		Items:       make([]*ir.Node, 0, len(items)),
+	}
+
+	for _, item := range items {
+		node, err := ir.Encode(item)
+		if err != nil {
+			return nil, err
+		}
+
+		request.Items = append(request.Items, node)
+	}
+
+	response, err := g.run(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]types.File, 0, len(response.Files))
+	for _, file := range response.Files {
+		// Plugins must not be able to write outside of the target's output path
+		name := path.Clean(file.Name)
+		if file.Name == "" || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("plugin `%s` returned an invalid file name: %q", g.target.Command[0], file.Name)
+		}
+
+		files = append(files, types.File{Name: name, Content: file.Content})
+	}
+
+	return files, nil
+}
+
+// run executes the plugin, sends the request on stdin and reads the response from stdout
+func (g *Generator) run(request *Request) (*Response, error) {
+	if len(g.target.Command) == 0 {
+		return nil, errors.New("no plugin command provided")
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	name := g.target.Command[0]
+	cmd := exec.Command(name, g.target.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		return nil, pluginError(name, err, stderr.String())
+	}
+
+	var response Response
+	if err = json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, pluginError(name, fmt.Errorf("invalid response: %w", err), stderr.String())
+	}
+
+	if response.Error != "" {
+		return nil, pluginError(name, errors.New(response.Error), stderr.String())
+	}
+
+	return &response, nil
+}
+
+// pluginError wraps an error with the plugin's name and whatever it wrote to stderr
+func pluginError(name string, err error, stderr string) error {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return fmt.Errorf("plugin `%s` failed: %w", name, err)
+	}
+
+	return fmt.Errorf("plugin `%s` failed: %w\n%s", name, err, stderr)
+}
+
+func fileContents(files []types.File) []string {
+	contents := make([]string, 0, len(files))
+	for _, file := range files {
+		contents = append(contents, file.Content)
+	}
+
+	return contents
+}
+
+var (
+	_ types.GeneratorInterface = &Generator{}
+	_ types.MultiFileGenerator = &Generator{}
+)
@@ -0,0 +1,135 @@
+package plugin_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/parser"
+	"go.trulyao.dev/mirror/v2/plugin"
+)
+
+// The test binary doubles as the plugin, the behaviour is selected with an environment variable
+const pluginModeEnv = "MIRROR_TEST_PLUGIN_MODE"
+
+type User struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(pluginModeEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "elm":
+		plugin.Run(func(request *plugin.Request) ([]plugin.File, error) {
+			var sb strings.Builder
+			for _, item := range request.Items {
+				fmt.Fprintf(&sb, "type alias %s%s =\n", request.Prefix, item.Name)
+				for _, field := range item.Fields {
+					fmt.Fprintf(&sb, "    %s : %s\n", field.Name, field.Item.Type)
+				}
+			}
+
+			return []plugin.File{{Name: request.FileName, Content: sb.String() + request.Options["footer"]}}, nil
+		})
+	case "error":
+		plugin.Run(func(*plugin.Request) ([]plugin.File, error) {
+			return nil, errors.New("maps are not supported")
+		})
+	case "crash":
+		fmt.Fprintln(os.Stderr, "panic: something went terribly wrong")
+		os.Exit(2)
+	case "garbage":
+		fmt.Print("not json")
+	case "escape":
+		plugin.Run(func(*plugin.Request) ([]plugin.File, error) {
+			return []plugin.File{{Name: "../../etc/passwd", Content: "oops"}}, nil
+		})
+	}
+
+	os.Exit(0)
+}
+
+func newTarget(t *testing.T, mode string) *plugin.Target {
+	t.Setenv(pluginModeEnv, mode)
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return plugin.New("elm", ".elm", executable).SetFileName("Types").SetOption("footer", "-- end")
+}
+
+func Test_PluginTarget(t *testing.T) {
+	target := newTarget(t, "elm")
+	if err := target.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	if target.Name() != "Types.elm" {
+		t.Errorf("expected file name to be `Types.elm`, got %q", target.Name())
+	}
+
+	p := parser.New()
+	if err := p.AddSource(reflect.TypeOf(User{})); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := target.Generator().(*plugin.Generator)
+	if err := gen.SetParser(p); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := gen.GenerateFiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "type alias User =\n    name : string\n    age : int\n-- end"
+	if len(files) != 1 || files[0].Name != "Types.elm" || files[0].Content != expected {
+		t.Errorf("unexpected files: %+v", files)
+	}
+}
+
+func Test_PluginErrors(t *testing.T) {
+	tests := []struct {
+		Mode   string
+		Expect []string
+	}{
+		{Mode: "error", Expect: []string{"maps are not supported"}},
+		{Mode: "crash", Expect: []string{"exit status 2", "panic: something went terribly wrong"}},
+		{Mode: "garbage", Expect: []string{"invalid response"}},
+		{Mode: "escape", Expect: []string{"invalid file name", "../../etc/passwd"}},
+	}
+
+	for _, test := range tests {
+		gen := newTarget(t, test.Mode).Generator()
+
+		_, err := gen.GenerateItem(&parser.Scalar{ItemName: "string", ItemType: parser.TypeString})
+		if err == nil {
+			t.Errorf("[%s] expected error, got nil", test.Mode)
+			continue
+		}
+
+		for _, expect := range test.Expect {
+			if !strings.Contains(err.Error(), expect) {
+				t.Errorf("[%s] expected error to contain %q, got %q", test.Mode, expect, err.Error())
+			}
+		}
+	}
+}
+
+func Test_PluginValidation(t *testing.T) {
+	if err := plugin.New("elm", "elm").Validate(); err == nil {
+		t.Errorf("expected missing command to fail validation")
+	}
+
+	if err := plugin.New("elm", "elm", "mirror-plugin-that-does-not-exist").Validate(); err == nil {
+		t.Errorf("expected missing executable to fail validation")
+	}
+}
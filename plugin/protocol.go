@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"go.trulyao.dev/mirror/v2/ir"
+)
+
+// ProtocolVersion is the version of the request/response format exchanged with plugins, it is bumped on breaking changes
+const ProtocolVersion = 1
+
+// Request is written (as JSON) to the plugin's stdin
+type Request struct {
+	// Version is the protocol version (see ProtocolVersion)
+	Version int `json:"version"`
+
+	// Language is the language the plugin is expected to generate (e.g. "elm")
+	Language string `json:"language"`
+
+	// FileName is the name of the file to generate when the plugin produces a single file
+	FileName string `json:"file_name"`
+
+	// Prefix is the prefix to add to the generated types
+	Prefix string `json:"prefix,omitempty"`
+
+	// Header is the header text to prepend to the generated files (if set by the user)
+	Header string `json:"header,omitempty"`
+
+	// CustomTypes maps fully-qualified Go types (e.g. `github.com/google/uuid.UUID`) to types in the target language
+	CustomTypes map[string]string `json:"custom_types,omitempty"`
+
+	// Options are arbitrary plugin-specific options set on the target
+	Options map[string]string `json:"options,omitempty"`
+
+	// Items is the full parsed item tree of every source
+	Items []*ir.Node `json:"items"`
+}
+
+// File is a single file generated by a plugin, the name is relative to the target's output path
+type File struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Response is read (as JSON) from the plugin's stdout
+type Response struct {
+	// Files are the generated files
+	Files []File `json:"files"`
+
+	// Error is a human-readable error message, plugins should set this (and exit with a zero status) for errors caused by the input, e.g. unsupported types
+	Error string `json:"error,omitempty"`
+}
+
+// GenerateFunc generates files for a request
+type GenerateFunc func(request *Request) ([]File, error)
+
+// Run implements the plugin side of the protocol for plugins written in Go: it reads the request from stdin, calls `fn` and writes the response to stdout
+// Errors returned by `fn` are reported in the response, so the plugin exits cleanly
+func Run(fn GenerateFunc) {
+	if err := Serve(os.Stdin, os.Stdout, fn); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Serve is like Run but reads the request from `r` and writes the response to `w`
+func Serve(r io.Reader, w io.Writer, fn GenerateFunc) error {
+	var request Request
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	var response Response
+	if request.Version != ProtocolVersion {
+		response.Error = fmt.Sprintf("unsupported protocol version %d, expected %d", request.Version, ProtocolVersion)
+	} else if files, err := fn(&request); err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Files = files
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	return nil
+}
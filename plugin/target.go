@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"errors"
+	"os/exec"
+	"path"
+	"strings"
+
+	"go.trulyao.dev/mirror/v2/types"
+)
+
+// Target is a target whose code is generated by an external program (a plugin), similar to protoc plugins
+// The plugin receives a `Request` (with the full parsed item tree) as JSON on stdin and writes a `Response` with the generated files as JSON to stdout
+type Target struct {
+	// The generator for the current instance
+	generator *Generator
+
+	// Command is the plugin executable followed by its arguments (e.g. `["mirror-elm", "--strict"]`)
+	Command []string
+
+	// Lang is the language the plugin generates (e.g. "elm")
+	Lang string
+
+	// Ext is the file extension of the generated files (e.g. "elm")
+	Ext string
+
+	// FileName is the name of the generated file when the plugin produces a single file
+	FileName string
+
+	// OutputPath is the path to write the generated files to
+	OutputPath string
+
+	// TypePrefix is the prefix to add to the generated types
+	TypePrefix string
+
+	// Options are arbitrary plugin-specific options passed to the plugin in the request
+	Options map[string]string
+
+	// customTypes maps fully-qualified Go types to types in the plugin's language, they are passed to the plugin in the request
+	customTypes map[string]string
+}
+
+// New returns a new plugin target for the language that runs the provided command
+func New(language, extension string, command ...string) *Target {
+	return &Target{
+		Command:     command,
+		Lang:        language,
+		Ext:         strings.TrimPrefix(extension, "."),
+		FileName:    "generated",
+		OutputPath:  "./",
+		Options:     make(map[string]string),
+		customTypes: make(map[string]string),
+	}
+}
+
+// ID returns a unique identifier for a target
+func (t *Target) ID() string {
+	return strings.ReplaceAll(path.Join(t.OutputPath, t.Name()), "/", ":")
+}
+
+// IsEquivalent checks if two targets are equivalent
+func (t *Target) IsEquivalent(target types.TargetInterface) bool {
+	return t.ID() == target.ID()
+}
+
+// Name returns the name of the file
+func (t *Target) Name() string {
+	if t.Ext == "" || strings.HasSuffix(t.FileName, "."+t.Ext) {
+		return t.FileName
+	}
+
+	return t.FileName + "." + t.Ext
+}
+
+// Path returns the path to write the files to
+func (t *Target) Path() string { return t.OutputPath }
+
+// Language returns the target language
+func (t *Target) Language() string { return t.Lang }
+
+// Extension returns the file extension
+func (t *Target) Extension() string { return t.Ext }
+
+// Header returns an empty string, plugins are responsible for the full content of the files they generate
+func (t *Target) Header() string { return "" }
+
+// Prefix returns the prefix to add to the generated types
+func (t *Target) Prefix() string { return t.TypePrefix }
+
+// SetFileName sets the name of the file to write to
+func (t *Target) SetFileName(name string) *Target {
+	t.FileName = name
+	return t
+}
+
+// SetOutputPath sets the path to write the files to
+func (t *Target) SetOutputPath(path string) *Target {
+	t.OutputPath = path
+	return t
+}
+
+// SetPrefix sets the prefix to add to the generated types
+func (t *Target) SetPrefix(value string) *Target {
+	t.TypePrefix = value
+	return t
+}
+
+// SetOption sets a plugin-specific option
+func (t *Target) SetOption(key, value string) *Target {
+	if t.Options == nil {
+		t.Options = make(map[string]string)
+	}
+
+	t.Options[key] = value
+	return t
+}
+
+// AddCustomType maps a fully-qualified Go type (e.g. `github.com/google/uuid.UUID`) to a type in the plugin's language
+func (t *Target) AddCustomType(name, value string) {
+	if t.customTypes == nil {
+		t.customTypes = make(map[string]string)
+	}
+
+	t.customTypes[name] = value
+}
+
+// Generator returns the generator that runs the plugin
+func (t *Target) Generator() types.GeneratorInterface {
+	if t.generator == nil {
+		t.generator = NewGenerator(t)
+	}
+
+	return t.generator
+}
+
+// Validate checks if the target is valid and the plugin can be found
+func (t *Target) Validate() error {
+	if len(t.Command) == 0 || t.Command[0] == "" {
+		return errors.New("no plugin command provided")
+	}
+
+	if t.Lang == "" {
+		return errors.New("no language provided")
+	}
+
+	if t.FileName == "" {
+		return errors.New("no file name provided")
+	}
+
+	if t.OutputPath == "" {
+		return errors.New("no output path provided")
+	}
+
+	if _, err := exec.LookPath(t.Command[0]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ types.TargetInterface = &Target{}
@@ -0,0 +1,217 @@
+// Package route extracts endpoints from `net/http` route registrations (Go 1.22 `http.ServeMux` patterns or chi-style routers) so that they can be passed to mirror to generate the types and clients for them
+//
+//	router := route.New(http.NewServeMux())
+//	router.Handle("GET /users/{id}", route.HandleFunc[route.None, User](getUser))
+//	router.Handle("POST /users", route.HandleFunc[CreateUserRequest, User](createUser))
+//
+//	m.AddEndpoints(router.Endpoints()...)
+package route
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.trulyao.dev/mirror/v2/endpoint"
+)
+
+// None is used as the request or response type of handlers that take no request or return no content (e.g. `route.Handle[route.None, User](h)`)
+type None struct{}
+
+// Handler is an http.Handler annotated with the request and response types of its endpoint
+type Handler struct {
+	http.Handler
+
+	// Name is the name of the endpoint in the generated clients, derived from the method and path when empty
+	Name string
+
+	// Request is the type of the request, nil if the handler takes no request
+	Request reflect.Type
+
+	// Response is the type of the response, nil if the handler returns no content
+	Response reflect.Type
+}
+
+// Handle annotates a handler with its request and response types
+func Handle[Request, Response any](handler http.Handler) *Handler {
+	return &Handler{Handler: handler, Request: typeOf[Request](), Response: typeOf[Response]()}
+}
+
+// HandleFunc annotates a handler function with its request and response types
+func HandleFunc[Request, Response any](handler func(http.ResponseWriter, *http.Request)) *Handler {
+	return Handle[Request, Response](http.HandlerFunc(handler))
+}
+
+// Named sets the name of the endpoint in the generated clients (e.g. `getUser`)
+func (h *Handler) Named(name string) *Handler {
+	h.Name = name
+	return h
+}
+
+// Mux is a router that registers handlers by pattern, for example `*http.ServeMux` (with Go 1.22 patterns like `GET /users/{id}`) or a chi router
+type Mux interface {
+	http.Handler
+
+	Handle(pattern string, handler http.Handler)
+}
+
+// MethodMux is a router that registers handlers by method and pattern (e.g. a chi router)
+type MethodMux interface {
+	Mux
+
+	Method(method, pattern string, handler http.Handler)
+}
+
+// Router wraps a mux and records the endpoints of the annotated handlers registered through it, handlers that are not annotated are registered as usual
+type Router struct {
+	mux Mux
+
+	mu        sync.Mutex
+	endpoints []endpoint.Endpoint
+	errs      []error
+}
+
+// New returns a new router that registers handlers with the mux
+func New(mux Mux) *Router {
+	return &Router{mux: mux}
+}
+
+// ServeHTTP dispatches the request to the mux
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+// Handle registers the handler for the pattern (e.g. `GET /users/{id}`) and records its endpoint if it is annotated, the pattern must include the method for annotated handlers
+func (r *Router) Handle(pattern string, handler http.Handler) {
+	r.record(Parse(pattern))(handler)
+	r.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers the handler function for the pattern, see Handle
+func (r *Router) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// Method registers the handler for the method and pattern (e.g. `/users/{id}`) and records its endpoint if it is annotated
+// Routers that implement MethodMux (e.g. chi) are used as they are, otherwise the handler is registered with a Go 1.22 pattern (e.g. `GET /users/{id}`)
+func (r *Router) Method(method, pattern string, handler http.Handler) {
+	r.record(strings.ToUpper(method), pattern, nil)(handler)
+
+	if mux, ok := r.mux.(MethodMux); ok {
+		mux.Method(method, pattern, handler)
+		return
+	}
+
+	r.mux.Handle(strings.ToUpper(method)+" "+pattern, handler)
+}
+
+// Add records the endpoint of an annotated handler without registering it, this is useful for routes that have already been registered (e.g. when walking a chi router with `chi.Walk`)
+func (r *Router) Add(method, pattern string, handler http.Handler) error {
+	e, ok, err := Endpoint(method, pattern, handler)
+	if err != nil || !ok {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.endpoints = append(r.endpoints, e)
+	return nil
+}
+
+// Endpoints returns the endpoints of the annotated handlers in the order they were registered
+func (r *Router) Endpoints() []endpoint.Endpoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoints := make([]endpoint.Endpoint, len(r.endpoints))
+	copy(endpoints, r.endpoints)
+
+	return endpoints
+}
+
+// Err returns the (joined) errors of the annotated handlers whose endpoints could not be recorded (e.g. a pattern without a method)
+func (r *Router) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return errors.Join(r.errs...)
+}
+
+// record returns a function that records the endpoint of a handler registered with the method and path
+func (r *Router) record(method, path string, err error) func(http.Handler) {
+	return func(handler http.Handler) {
+		if _, ok := handler.(*Handler); !ok {
+			return
+		}
+
+		if err == nil {
+			err = r.Add(method, path, handler)
+		}
+
+		if err != nil {
+			r.mu.Lock()
+			r.errs = append(r.errs, err)
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Endpoint returns the endpoint of an annotated handler registered with the method and path (e.g. `/users/{id}`), false is returned for handlers that are not annotated
+// Chi-style wildcards (`/files/*`) are converted to Go 1.22 wildcards (`/files/{path...}`)
+func Endpoint(method, path string, handler http.Handler) (endpoint.Endpoint, bool, error) {
+	h, ok := handler.(*Handler)
+	if !ok {
+		return endpoint.Endpoint{}, false, nil
+	}
+
+	if method == "" {
+		return endpoint.Endpoint{}, false, fmt.Errorf("no method in pattern for `%s`, annotated handlers must be registered with a method (e.g. `GET %s`)", path, path)
+	}
+
+	if strings.HasSuffix(path, "/*") {
+		path = strings.TrimSuffix(path, "*") + "{path...}"
+	}
+
+	e := endpoint.Endpoint{
+		Name:     h.Name,
+		Method:   strings.ToUpper(method),
+		Path:     path,
+		Request:  h.Request,
+		Response: h.Response,
+	}
+
+	if err := e.Validate(); err != nil {
+		return endpoint.Endpoint{}, false, err
+	}
+
+	return e, true, nil
+}
+
+// Parse splits a Go 1.22 pattern (`[METHOD ][HOST]/[PATH]`) into its method and path, the host is dropped since clients are created with a base URL
+func Parse(pattern string) (method string, path string, err error) {
+	pattern = strings.TrimSpace(pattern)
+	if before, after, found := strings.Cut(pattern, " "); found {
+		method, pattern = before, strings.TrimLeft(after, " \t")
+	}
+
+	idx := strings.Index(pattern, "/")
+	if idx == -1 {
+		return "", "", fmt.Errorf("invalid pattern `%s`, expected a path", pattern)
+	}
+
+	return method, pattern[idx:], nil
+}
+
+// typeOf returns the type of T, or nil for None
+func typeOf[T any]() reflect.Type {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t == reflect.TypeOf(None{}) {
+		return nil
+	}
+
+	return t
+}
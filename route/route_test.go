@@ -0,0 +1,146 @@
+package route_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"go.trulyao.dev/mirror/v2/endpoint"
+	"go.trulyao.dev/mirror/v2/route"
+)
+
+type (
+	User struct {
+		Name string `json:"name"`
+	}
+
+	CreateUserRequest struct {
+		Name string `json:"name"`
+	}
+)
+
+// mux records the patterns handlers are registered with
+type mux struct {
+	http.ServeMux
+	patterns []string
+}
+
+func (m *mux) Handle(pattern string, handler http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+}
+
+// methodMux records the methods and patterns handlers are registered with, like a chi router
+type methodMux struct {
+	mux
+}
+
+func (m *methodMux) Method(method, pattern string, handler http.Handler) {
+	m.patterns = append(m.patterns, method+":"+pattern)
+}
+
+func noop(http.ResponseWriter, *http.Request) {}
+
+func Test_Router(t *testing.T) {
+	m := &mux{}
+	router := route.New(m)
+
+	router.Handle("GET /users/{id}", route.HandleFunc[route.None, User](noop))
+	router.HandleFunc("GET /health", noop)
+	router.Handle("POST example.com/users", route.HandleFunc[CreateUserRequest, User](noop).Named("createUser"))
+	router.Method("delete", "/users/{id}", route.HandleFunc[route.None, route.None](noop))
+
+	expectPatterns := []string{"GET /users/{id}", "GET /health", "POST example.com/users", "DELETE /users/{id}"}
+	if !reflect.DeepEqual(m.patterns, expectPatterns) {
+		t.Errorf("expected handlers to be registered with %v, got %v", expectPatterns, m.patterns)
+	}
+
+	expectEndpoints := []endpoint.Endpoint{
+		{Method: "GET", Path: "/users/{id}", Response: reflect.TypeOf(User{})},
+		{Name: "createUser", Method: "POST", Path: "/users", Request: reflect.TypeOf(CreateUserRequest{}), Response: reflect.TypeOf(User{})},
+		{Method: "DELETE", Path: "/users/{id}"},
+	}
+	if got := router.Endpoints(); !reflect.DeepEqual(got, expectEndpoints) {
+		t.Errorf("expected endpoints %v, got %v", expectEndpoints, got)
+	}
+
+	if err := router.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func Test_RouterErrors(t *testing.T) {
+	router := route.New(&mux{})
+	router.Handle("/users", route.HandleFunc[route.None, User](noop))
+	router.Handle("/legacy", http.HandlerFunc(noop))
+
+	if len(router.Endpoints()) != 0 {
+		t.Errorf("expected no endpoints, got %v", router.Endpoints())
+	}
+
+	if router.Err() == nil {
+		t.Error("expected an error for an annotated handler without a method, got nil")
+	}
+}
+
+func Test_RouterMethodMux(t *testing.T) {
+	m := &methodMux{}
+	router := route.New(m)
+	router.Method("GET", "/files/*", route.HandleFunc[route.None, []byte](noop))
+
+	if expect := []string{"GET:/files/*"}; !reflect.DeepEqual(m.patterns, expect) {
+		t.Errorf("expected handlers to be registered with %v, got %v", expect, m.patterns)
+	}
+
+	expect := []endpoint.Endpoint{{Method: "GET", Path: "/files/{path...}", Response: reflect.TypeOf([]byte{})}}
+	if got := router.Endpoints(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected endpoints %v, got %v", expect, got)
+	}
+}
+
+func Test_RouterServeHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTeapot) })
+
+	recorder := httptest.NewRecorder()
+	route.New(mux).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	if recorder.Code != http.StatusTeapot {
+		t.Errorf("expected the request to be served by the mux, got status %d", recorder.Code)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		Description string
+		Pattern     string
+		Method      string
+		Path        string
+		WantErr     bool
+	}{
+		{Description: "method and path", Pattern: "GET /users/{id}", Method: "GET", Path: "/users/{id}"},
+		{Description: "path only", Pattern: "/users", Path: "/users"},
+		{Description: "method, host and path", Pattern: "POST example.com/users", Method: "POST", Path: "/users"},
+		{Description: "extra spaces", Pattern: "GET   /users", Method: "GET", Path: "/users"},
+		{Description: "missing path", Pattern: "GET example.com", WantErr: true},
+	}
+
+	for _, test := range tests {
+		method, path, err := route.Parse(test.Pattern)
+		if test.WantErr {
+			if err == nil {
+				t.Errorf("[%s] expected an error, got nil", test.Description)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", test.Description, err)
+			continue
+		}
+
+		if method != test.Method || path != test.Path {
+			t.Errorf("[%s] expected %q %q, got %q %q", test.Description, test.Method, test.Path, method, path)
+		}
+	}
+}
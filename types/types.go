@@ -3,6 +3,7 @@ package types
 import (
 	"reflect"
 
+	"go.trulyao.dev/mirror/v2/endpoint"
 	"go.trulyao.dev/mirror/v2/parser"
 )
 
@@ -64,6 +65,38 @@ type ParserInterface interface {
 	OnParseField(fn parser.OnParseFieldFunc)
 }
 
+// An optional extension of the parser interface for parsers that are able to parse interfaces as discriminated unions (Go does not keep track of the implementations of an interface at runtime)
+type UnionParser interface {
+	ParserInterface
+
+	// Register the implementations of an interface (with their discriminator values) to be parsed as a discriminated union
+	AddUnion(reflect.Type, string, ...parser.VariantSource) error
+}
+
+// An optional extension of the parser interface for parsers that are able to derive the default values of fields from a value of the source (e.g. the result of a constructor)
+type DefaultsParser interface {
+	ParserInterface
+
+	// Add a struct source and use the values of its fields as their default values
+	AddSourceWithDefaults(any) error
+}
+
+// An optional extension of the parser interface for parsers that are able to name the parameters of function types (Go does not keep parameter names at runtime)
+type ParamNamesParser interface {
+	ParserInterface
+
+	// Register the names of the parameters of a function type
+	SetParamNames(reflect.Type, ...string) error
+}
+
+// An optional extension of the parser interface for parsers that are able to parse enums (Go does not keep track of the constants of a type at runtime)
+type EnumParser interface {
+	ParserInterface
+
+	// Add an enum source along with all of its values
+	AddEnum(...any) error
+}
+
 // A general language interface to make it harder to pass in a wrong language or extend the built-in languages and backends in the future
 // There will clearly be neglibile performance impact but it should not matter much here
 type TargetInterface interface {
@@ -124,3 +157,28 @@ type GeneratorInterface interface {
 	// This is mostly useful for testing purposes
 	SetNonStrict(bool)
 }
+
+// A single generated file, the name is relative to the target's output path (e.g. "models/user.ts")
+type File struct {
+	// The file name (with the extension) relative to the target's output path
+	Name string
+
+	// The full content of the file
+	Content string
+}
+
+// An optional extension of the generator interface for generators that are able to split the generated code across multiple files (e.g. one file per package)
+type MultiFileGenerator interface {
+	GeneratorInterface
+
+	// Generate all types grouped into files based on the target's configuration, a single file is returned if the target is not configured to split its output
+	GenerateFiles() ([]File, error)
+}
+
+// An optional extension of the generator interface for generators that generate code for the registered HTTP endpoints (e.g. a typed client)
+type EndpointGenerator interface {
+	GeneratorInterface
+
+	// Set the endpoints to generate code for
+	SetEndpoints([]endpoint.Endpoint)
+}
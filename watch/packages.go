@@ -0,0 +1,77 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// Package is a Go package resolved by the go tool
+type Package struct {
+	// ImportPath is the import path of the package (e.g. `example.com/app/models`)
+	ImportPath string
+
+	// Dir is the directory containing the package's source files
+	Dir string
+}
+
+// mainPackage is the package path of types declared in a `main` package, it is not an import path the go tool can resolve
+const mainPackage = "main"
+
+// MainPackage returns the import path of the running program's main package, an empty string is returned if it is unknown (e.g. for `go run main.go` or test binaries)
+func MainPackage() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Path == "" || info.Path == "command-line-arguments" || strings.HasSuffix(info.Path, ".test") {
+		return ""
+	}
+
+	return info.Path
+}
+
+// ResolvePackages resolves the directories of the provided import paths using `go list`, standard library packages are skipped since they cannot change
+// The `main` package (of types declared in package main) is resolved to the running program's main package or to the package in the working directory if it is unknown
+func ResolvePackages(ctx context.Context, importPaths ...string) ([]Package, error) {
+	if len(importPaths) == 0 {
+		return nil, nil
+	}
+
+	var stderr bytes.Buffer
+
+	patterns := make([]string, 0, len(importPaths))
+	for _, importPath := range importPaths {
+		if importPath == mainPackage {
+			if importPath = MainPackage(); importPath == "" {
+				importPath = "."
+			}
+		}
+
+		if !slices.Contains(patterns, importPath) {
+			patterns = append(patterns, importPath)
+		}
+	}
+
+	args := append([]string{"list", "-find", "-f", "{{.ImportPath}}\t{{.Dir}}\t{{.Standard}}"}, patterns...)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve packages: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var packages []Package
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "true" {
+			continue
+		}
+
+		packages = append(packages, Package{ImportPath: parts[0], Dir: parts[1]})
+	}
+
+	return packages, nil
+}
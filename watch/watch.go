@@ -0,0 +1,234 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvWatch is set (to "1") in the environment of commands run by the watcher, generators can use it to print extra diagnostics
+	EnvWatch = "MIRROR_WATCH"
+
+	// EnvChangedPackages holds the comma-separated import paths of the packages that changed in the environment of commands run by the watcher, it is empty when they are unknown (e.g. on the first run)
+	// Mirror uses it to only regenerate the targets whose sources are affected by the changes
+	EnvChangedPackages = "MIRROR_CHANGED_PACKAGES"
+
+	// recursiveSuffix marks a directory to be watched recursively (e.g. `./...`)
+	recursiveSuffix = "/..."
+)
+
+var ErrNoDirectories = errors.New("no directories to watch")
+
+type Options struct {
+	// Interval is how often the directories are checked for changes (defaults to 500ms)
+	Interval time.Duration
+
+	// Debounce is how long to wait after the last change before notifying, so that a burst of changes (e.g. saving multiple files or switching branches) only triggers a single notification (defaults to 200ms)
+	Debounce time.Duration
+}
+
+// fileState is the state of a file used to detect changes
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher watches directories for changes to Go source files (test files are ignored) by polling them
+type Watcher struct {
+	dirs []string
+	opts Options
+}
+
+// New creates a new watcher for the provided directories, directories ending in `/...` are watched recursively
+func New(opts Options, dirs ...string) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 500 * time.Millisecond
+	}
+
+	if opts.Debounce <= 0 {
+		opts.Debounce = 200 * time.Millisecond
+	}
+
+	return &Watcher{dirs: dirs, opts: opts}
+}
+
+// Run watches the directories until the context is cancelled, `onChange` is called with the sorted paths of the files that were created, modified or removed once the changes settle
+// Run returns nil when the context is cancelled
+func (w *Watcher) Run(ctx context.Context, onChange func(changed []string)) error {
+	if len(w.dirs) == 0 {
+		return ErrNoDirectories
+	}
+
+	previous, err := w.scan()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	var (
+		pending    = make(map[string]struct{})
+		lastChange time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := w.scan()
+			if err != nil {
+				return err
+			}
+
+			if changed := diff(previous, current); len(changed) > 0 {
+				for _, file := range changed {
+					pending[file] = struct{}{}
+				}
+				lastChange = now
+			}
+			previous = current
+
+			if len(pending) == 0 || now.Sub(lastChange) < w.opts.Debounce {
+				continue
+			}
+
+			changed := make([]string, 0, len(pending))
+			for file := range pending {
+				changed = append(changed, file)
+			}
+			slices.Sort(changed)
+			clear(pending)
+
+			onChange(changed)
+		}
+	}
+}
+
+// scan returns the state of every watched Go file
+func (w *Watcher) scan() (map[string]fileState, error) {
+	files := make(map[string]fileState)
+
+	for _, dir := range w.dirs {
+		root, recursive := strings.CutSuffix(filepath.ToSlash(dir), recursiveSuffix)
+		if root == "" {
+			root = "."
+		}
+
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				// Directories can be removed while they are being walked (e.g. when switching branches)
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+
+				return err
+			}
+
+			if entry.IsDir() {
+				if path == root {
+					return nil
+				}
+
+				if !recursive || skipDir(entry.Name()) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+
+				return err
+			}
+
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// skipDir checks if a directory should be skipped when watching recursively (the same directories the go tool ignores)
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" || name == "node_modules"
+}
+
+// diff returns the files that were created, modified or removed between two scans
+func diff(previous, current map[string]fileState) []string {
+	var changed []string
+
+	for path, state := range current {
+		if previousState, ok := previous[path]; !ok || previousState != state {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
+
+// RunCommand runs a command (usually the program that generates the types, e.g. `go run ./cmd/gen`) with the watch environment variables set, the command's output is written to `w`
+// `changedPackages` should be nil when the changed packages are unknown, every target is regenerated in that case
+func RunCommand(ctx context.Context, w io.Writer, changedPackages []string, command ...string) error {
+	if len(command) == 0 {
+		return errors.New("no command provided")
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	cmd.Env = append(os.Environ(), EnvWatch+"=1", EnvChangedPackages+"="+strings.Join(changedPackages, ","))
+
+	return cmd.Run()
+}
+
+// Regenerate runs the generator command with RunCommand and reports how long it took (or why it failed) to `w`, failures caused by the context being cancelled are not reported
+func Regenerate(ctx context.Context, w io.Writer, changedPackages []string, command ...string) {
+	start := time.Now()
+	if err := RunCommand(ctx, w, changedPackages, command...); err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(w, "mirror: generation failed: %s\n", err)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "mirror: generated in %s\n", time.Since(start).Round(time.Millisecond))
+}
+
+// ChangedPackages returns the packages that changed according to the environment of a command run by the watcher, false is returned if they are unknown (e.g. when not running under a watcher)
+func ChangedPackages() ([]string, bool) {
+	value := os.Getenv(EnvChangedPackages)
+	if os.Getenv(EnvWatch) == "" || value == "" {
+		return nil, false
+	}
+
+	return strings.Split(value, ","), true
+}
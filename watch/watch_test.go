@@ -0,0 +1,120 @@
+package watch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"go.trulyao.dev/mirror/v2/watch"
+)
+
+func Test_Watch(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "models")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(filepath.Join(root, "main.go"), "package main")
+	writeFile(filepath.Join(nested, "user.go"), "package models")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notifications := make(chan []string, 10)
+	done := make(chan error, 1)
+
+	w := watch.New(watch.Options{Interval: 10 * time.Millisecond, Debounce: 50 * time.Millisecond}, root+"/...")
+	go func() {
+		done <- w.Run(ctx, func(changed []string) { notifications <- changed })
+	}()
+
+	// Give the watcher time to take the initial snapshot
+	time.Sleep(50 * time.Millisecond)
+
+	writeFile(filepath.Join(nested, "user.go"), "package models\n\ntype User struct{}")
+	writeFile(filepath.Join(nested, "user_test.go"), "package models")
+	writeFile(filepath.Join(nested, "README.md"), "# models")
+	writeFile(filepath.Join(nested, "post.go"), "package models")
+
+	select {
+	case changed := <-notifications:
+		expected := []string{filepath.Join(nested, "post.go"), filepath.Join(nested, "user.go")}
+		if !slices.Equal(changed, expected) {
+			t.Errorf("expected %v to have changed, got %v", expected, changed)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for changes")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("expected watcher to stop cleanly, got %v", err)
+	}
+
+	select {
+	case changed := <-notifications:
+		t.Errorf("expected changes to be debounced into a single notification, got another one: %v", changed)
+	default:
+	}
+}
+
+func Test_WatchNonRecursive(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "models")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	notifications := make(chan []string, 10)
+	w := watch.New(watch.Options{Interval: 10 * time.Millisecond, Debounce: 20 * time.Millisecond}, root)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = os.WriteFile(filepath.Join(nested, "user.go"), []byte("package models"), 0o644)
+	}()
+
+	if err := w.Run(ctx, func(changed []string) { notifications <- changed }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notifications) != 0 {
+		t.Errorf("expected nested directories to be ignored, got %v", <-notifications)
+	}
+}
+
+func Test_ChangedPackages(t *testing.T) {
+	tests := []struct {
+		Description string
+		Watch       string
+		Changed     string
+		Expect      []string
+		Known       bool
+	}{
+		{Description: "not running under a watcher", Watch: "", Changed: "example.com/app/models", Known: false},
+		{Description: "unknown changes", Watch: "1", Changed: "", Known: false},
+		{Description: "changed packages", Watch: "1", Changed: "example.com/app/models,example.com/app/api", Expect: []string{"example.com/app/models", "example.com/app/api"}, Known: true},
+	}
+
+	for _, test := range tests {
+		t.Setenv(watch.EnvWatch, test.Watch)
+		t.Setenv(watch.EnvChangedPackages, test.Changed)
+
+		changed, known := watch.ChangedPackages()
+		if known != test.Known || !slices.Equal(changed, test.Expect) {
+			t.Errorf("[%s] expected (%v, %v), got (%v, %v)", test.Description, test.Expect, test.Known, changed, known)
+		}
+	}
+}